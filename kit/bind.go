@@ -0,0 +1,238 @@
+package kit
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/textproto"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// TypedHandlerFunc defines a handler that receives an already bound and validated request.
+type TypedHandlerFunc[Req, Resp any] func(ctx *gin.Context, req *Req) (Resp, error)
+
+// Handle converts a TypedHandlerFunc into a HandlerFunc, so it can be registered
+// through RouterGroup like any other handler.
+// The request is bound with BindRequest before fun is called.
+// RouterGroup records the Req and Resp types of the route, they describe it in the OpenAPI document.
+func Handle[Req, Resp any](fun TypedHandlerFunc[Req, Resp]) HandlerFunc {
+	handler := HandlerFunc(func(ctx *gin.Context) (any, error) {
+		if ctx == describeContext {
+			return handlerTypes{request: requestType[Req](), response: reflect.TypeFor[Resp]()}, nil
+		}
+
+		req := new(Req)
+		if err := BindRequest(ctx, req); err != nil {
+			return nil, err
+		}
+
+		resp, err := fun(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return resp, nil
+	})
+	typedHandlers.Store(reflect.ValueOf(handler).Pointer(), struct{}{})
+	return handler
+}
+
+// typedHandlers holds the code pointers of the handlers built by Handle. Only those handlers
+// are called with describeContext, which makes them return their handlerTypes instead of
+// serving a request.
+var (
+	typedHandlers   sync.Map // uintptr -> struct{}
+	describeContext = &gin.Context{}
+)
+
+type handlerTypes struct {
+	request  reflect.Type
+	response reflect.Type
+}
+
+// describeHandler returns the request and response types of a handler built by Handle,
+// and nil types for any other handler.
+func describeHandler(handler HandlerFunc) (request, response reflect.Type) {
+	if handler == nil {
+		return nil, nil
+	}
+	if _, ok := typedHandlers.Load(reflect.ValueOf(handler).Pointer()); !ok {
+		return nil, nil
+	}
+	described, _ := handler(describeContext)
+	types := described.(handlerTypes)
+	return types.request, types.response
+}
+
+// requestType returns the type of Req, or nil for an empty struct which carries no request.
+func requestType[Req any]() reflect.Type {
+	t := reflect.TypeFor[Req]()
+	if t.Kind() == reflect.Struct && t.NumField() == 0 {
+		return nil
+	}
+	return t
+}
+
+// BindRequest binds the body, query, header and path parameters into req,
+// in that order, then runs the `binding` validator tags once on the result.
+// Query, header and path parameters only bind into the fields carrying a `form`,
+// `header` or `uri` tag, so that they cannot overwrite body fields.
+// Binding and validation failures are returned as an ErrInvalidArgument Exception
+// listing the bad fields, validation failures also carry a BadRequest detail.
+func BindRequest(ctx *gin.Context, req any) error {
+	typ := reflect.TypeOf(req)
+	binders := []func() error{
+		func() error {
+			if ctx.Request.Body == nil || ctx.Request.ContentLength == 0 {
+				return nil
+			}
+			body := binding.Default(ctx.Request.Method, ctx.ContentType())
+			if body == binding.Form {
+				// binding.Form maps the query along with the body, FormPost only maps the body.
+				body = binding.FormPost
+			}
+			return ctx.ShouldBindWith(req, body)
+		},
+		func() error {
+			query := filterValues(ctx.Request.URL.Query(), bindNames(typ, "form", nil))
+			return binding.MapFormWithTag(req, query, "form")
+		},
+		func() error {
+			header := filterValues(ctx.Request.Header, bindNames(typ, "header", textproto.CanonicalMIMEHeaderKey))
+			return binding.Header.Bind(&http.Request{Header: header}, req)
+		},
+		func() error {
+			params := make(map[string][]string, len(ctx.Params))
+			for _, param := range ctx.Params {
+				params[param.Key] = []string{param.Value}
+			}
+			return binding.MapFormWithTag(req, filterValues(params, bindNames(typ, "uri", nil)), "uri")
+		},
+	}
+
+	for _, bind := range binders {
+		// Every gin binding validates the whole struct, which fails while
+		// the other sources are not bound yet, so validation is deferred.
+		if err := bind(); err != nil && !isValidationError(err) {
+			return NewInvalidArgumentError().WithErr(err)
+		}
+	}
+
+	if binding.Validator == nil {
+		return nil
+	}
+	if err := binding.Validator.ValidateStruct(req); err != nil {
+		return newValidationException(err)
+	}
+	return nil
+}
+
+type bindNamesKey struct {
+	typ reflect.Type
+	tag string
+}
+
+var bindNamesCache sync.Map // bindNamesKey -> map[string]bool
+
+// bindNames returns the parameter names a source may bind into typ: the names of the fields
+// carrying tag. Gin falls back to the Go field name for untagged fields, so those names are
+// excluded, even when another field uses them as tag. canonical normalizes names, if not nil.
+func bindNames(typ reflect.Type, tag string, canonical func(string) string) map[string]bool {
+	key := bindNamesKey{typ: typ, tag: tag}
+	if names, ok := bindNamesCache.Load(key); ok {
+		return names.(map[string]bool)
+	}
+
+	tagged, untagged := map[string]bool{}, map[string]bool{}
+	collectBindNames(typ, tag, tagged, untagged, map[reflect.Type]bool{})
+	names := make(map[string]bool, len(tagged))
+	for name := range tagged {
+		if canonical != nil {
+			name = canonical(name)
+		}
+		names[name] = true
+	}
+	for name := range untagged {
+		if canonical != nil {
+			name = canonical(name)
+		}
+		delete(names, name)
+	}
+
+	bindNamesCache.Store(key, names)
+	return names
+}
+
+func collectBindNames(typ reflect.Type, tag string, tagged, untagged map[string]bool, visited map[reflect.Type]bool) {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct || visited[typ] {
+		return
+	}
+	visited[typ] = true
+
+	for i := range typ.NumField() {
+		field := typ.Field(i)
+		if !field.IsExported() && !field.Anonymous {
+			continue
+		}
+		value := field.Tag.Get(tag)
+		if value == "-" {
+			continue
+		}
+
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+		name, _, _ := strings.Cut(value, ",")
+		switch {
+		case name != "":
+			tagged[name] = true
+		case !field.Anonymous || fieldType.Kind() != reflect.Struct:
+			untagged[field.Name] = true
+		}
+		collectBindNames(fieldType, tag, tagged, untagged, visited)
+	}
+}
+
+func filterValues(values map[string][]string, names map[string]bool) map[string][]string {
+	filtered := make(map[string][]string, len(names))
+	for name, value := range values {
+		if names[name] {
+			filtered[name] = value
+		}
+	}
+	return filtered
+}
+
+func isValidationError(err error) bool {
+	var validationErrors validator.ValidationErrors
+	return errors.As(err, &validationErrors)
+}
+
+func newValidationException(err error) *Exception {
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return NewInvalidArgumentError().WithErr(err)
+	}
+
+	fields := make([]string, 0, len(validationErrors))
+	violations := make([]FieldViolation, 0, len(validationErrors))
+	for _, fieldError := range validationErrors {
+		fields = append(fields, fmt.Sprintf("%s: %s", fieldError.Field(), fieldError.Tag()))
+		violations = append(violations, FieldViolation{
+			Field:       fieldError.Field(),
+			Description: fmt.Sprintf("failed on the '%s' validation", fieldError.Tag()),
+		})
+	}
+	return NewInvalidArgumentError().
+		WithErr(errors.New(strings.Join(fields, "; "))).
+		WithDetails(BadRequest{FieldViolations: violations})
+}
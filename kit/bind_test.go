@@ -0,0 +1,151 @@
+package kit
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type updateUserReq struct {
+	ID        int    `uri:"id" binding:"required"`
+	Name      string `json:"name" binding:"required"`
+	Age       int    `json:"age" binding:"gte=0,lte=150"`
+	Notify    bool   `form:"notify"`
+	RequestID string `header:"X-Request-Id" binding:"required"`
+}
+
+type updateUserResp struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	Notify    bool   `json:"notify"`
+	RequestID string `json:"request_id"`
+}
+
+func setupTypedApp() *gin.Engine {
+	r := gin.New()
+	NewRouterGroup(&r.RouterGroup).PUT("/users/:id", Handle(func(ctx *gin.Context, req *updateUserReq) (updateUserResp, error) {
+		return updateUserResp{ID: req.ID, Name: req.Name, Notify: req.Notify, RequestID: req.RequestID}, nil
+	}))
+	return r
+}
+
+func serveTyped(app *gin.Engine, path, body string, header http.Header) RespBody {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPut, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	app.ServeHTTP(w, req)
+
+	respBody := RespBody{}
+	_ = json.Unmarshal(w.Body.Bytes(), &respBody)
+	return respBody
+}
+
+func TestHandle(t *testing.T) {
+	app := setupTypedApp()
+
+	t.Run("binds path, query, header and body", func(t *testing.T) {
+		respBody := serveTyped(app, "/users/7?notify=true", `{"name":"alice","age":30}`,
+			http.Header{"X-Request-Id": {"req-1"}})
+
+		assert.True(t, respBody.Succeeded)
+		assert.Equal(t, map[string]any{
+			"id":         float64(7),
+			"name":       "alice",
+			"notify":     true,
+			"request_id": "req-1",
+		}, respBody.RespData)
+	})
+
+	t.Run("validation failure lists bad fields", func(t *testing.T) {
+		respBody := serveTyped(app, "/users/7", `{"age":200}`, nil)
+
+		assert.False(t, respBody.Succeeded)
+		assert.Equal(t, ErrInvalidArgument, respBody.Code)
+		assert.Contains(t, respBody.Desc, "Name: required")
+		assert.Contains(t, respBody.Desc, "Age: lte")
+		assert.Contains(t, respBody.Desc, "RequestID: required")
+		assert.Len(t, respBody.Details, 1)
+		assert.Equal(t, []FieldViolation{
+			{Field: "Name", Description: "failed on the 'required' validation"},
+			{Field: "Age", Description: "failed on the 'lte' validation"},
+			{Field: "RequestID", Description: "failed on the 'required' validation"},
+		}, respBody.Details[0].(BadRequest).FieldViolations)
+	})
+
+	t.Run("malformed body", func(t *testing.T) {
+		respBody := serveTyped(app, "/users/7", `{"name":`, http.Header{"X-Request-Id": {"req-1"}})
+
+		assert.False(t, respBody.Succeeded)
+		assert.Equal(t, ErrInvalidArgument, respBody.Code)
+	})
+
+	t.Run("malformed path parameter", func(t *testing.T) {
+		respBody := serveTyped(app, "/users/abc", `{"name":"alice"}`, http.Header{"X-Request-Id": {"req-1"}})
+
+		assert.False(t, respBody.Succeeded)
+		assert.Equal(t, ErrInvalidArgument, respBody.Code)
+	})
+
+	t.Run("query, header and path do not overwrite body fields", func(t *testing.T) {
+		type probeReq struct {
+			Name   string `json:"name"`
+			Role   string `json:"role"`
+			Notify bool   `form:"notify" json:"notify"`
+			Trace  string `header:"x-trace-id"`
+		}
+		r := gin.New()
+		NewRouterGroup(&r.RouterGroup).PUT("/probe/:Name", Handle(func(ctx *gin.Context, req *probeReq) (probeReq, error) {
+			return *req, nil
+		}))
+
+		respBody := serveTyped(r, "/probe/path?Name=q&Role=q&notify=true",
+			`{"name":"alice","role":"user","notify":false}`,
+			http.Header{"Role": {"hdr"}, "Name": {"hdr"}, "X-Trace-Id": {"t-1"}})
+
+		assert.True(t, respBody.Succeeded)
+		assert.Equal(t, map[string]any{
+			"name":   "alice",
+			"role":   "user",
+			"notify": true,
+			"Trace":  "t-1",
+		}, respBody.RespData)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPut, "/probe/path?Role=admin&notify=true", strings.NewReader("Name=bob"))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		r.ServeHTTP(w, req)
+
+		respBody = RespBody{}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &respBody))
+		assert.True(t, respBody.Succeeded)
+		assert.Equal(t, map[string]any{
+			"name":   "bob",
+			"role":   "",
+			"notify": true,
+			"Trace":  "",
+		}, respBody.RespData, "form bodies do not bind the query")
+	})
+
+	t.Run("handler error is passed through", func(t *testing.T) {
+		r := gin.New()
+		r.GET("/fail", TranslateFunc(Handle(func(ctx *gin.Context, req *struct{}) (any, error) {
+			return nil, NewNotFoundError()
+		})))
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/fail", http.NoBody)
+		r.ServeHTTP(w, req)
+
+		respBody := RespBody{}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &respBody))
+		assert.Equal(t, ErrNotFound, respBody.Code)
+	})
+}
@@ -0,0 +1,178 @@
+package kit
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LocaleKey is the gin context key that overrides the negotiated locale.
+const LocaleKey = "kit/locale"
+
+type localeContextKey struct{}
+
+// ContextWithLocale returns a copy of ctx carrying the locale,
+// which takes precedence over the Accept-Language header.
+func ContextWithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, localeContextKey{}, locale)
+}
+
+// LocaleFromContext returns the locale stored by ContextWithLocale.
+func LocaleFromContext(ctx context.Context) (string, bool) {
+	locale, ok := ctx.Value(localeContextKey{}).(string)
+	return locale, ok && locale != ""
+}
+
+// Catalog holds business messages keyed by locale and code.
+// Lookups fall back from the requested locale to its base language,
+// then to the default locale and finally to Messages.
+type Catalog struct {
+	mu            sync.RWMutex
+	defaultLocale string
+	messages      map[string]map[int]string
+}
+
+// DefaultCatalog is the catalog used by TranslateFunc, it ships English and Chinese messages.
+var DefaultCatalog = newDefaultCatalog()
+
+// NewCatalog creates an empty catalog whose fallback locale is defaultLocale.
+func NewCatalog(defaultLocale string) *Catalog {
+	return &Catalog{
+		defaultLocale: normalizeLocale(defaultLocale),
+		messages:      map[string]map[int]string{},
+	}
+}
+
+// Register adds or replaces the messages of a locale.
+func (c *Catalog) Register(locale string, messages map[int]string) {
+	locale = normalizeLocale(locale)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.messages[locale] == nil {
+		c.messages[locale] = map[int]string{}
+	}
+	for code, message := range messages {
+		c.messages[locale][code] = message
+	}
+}
+
+// Message returns the message of code in locale, following the fallback chain.
+func (c *Catalog) Message(locale string, code int) (string, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	locale = normalizeLocale(locale)
+	for _, candidate := range []string{locale, baseLanguage(locale), c.defaultLocale} {
+		if message, ok := c.messages[candidate][code]; ok {
+			return message, true
+		}
+	}
+	message, ok := Messages[code]
+	return message, ok
+}
+
+// Negotiate picks the locale of the request, in order of preference:
+// the LocaleKey gin value, the ContextWithLocale value of the request context,
+// the best supported Accept-Language entry, and the default locale.
+func (c *Catalog) Negotiate(ctx *gin.Context) string {
+	if locale := ctx.GetString(LocaleKey); locale != "" {
+		return normalizeLocale(locale)
+	}
+	if locale, ok := LocaleFromContext(ctx.Request.Context()); ok {
+		return normalizeLocale(locale)
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for _, tag := range parseAcceptLanguage(ctx.GetHeader("Accept-Language")) {
+		if c.supports(tag) {
+			return tag
+		}
+		if base := baseLanguage(tag); c.supports(base) {
+			return base
+		}
+	}
+	return c.defaultLocale
+}
+
+func (c *Catalog) supports(locale string) bool {
+	_, ok := c.messages[locale]
+	return ok || locale == c.defaultLocale
+}
+
+// parseAcceptLanguage returns the language tags of the header ordered by quality.
+func parseAcceptLanguage(header string) []string {
+	type weighted struct {
+		tag     string
+		quality float64
+	}
+
+	var tags []weighted
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		tag = normalizeLocale(tag)
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		quality := 1.0
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(q, 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+		if quality > 0 {
+			tags = append(tags, weighted{tag: tag, quality: quality})
+		}
+	}
+
+	sort.SliceStable(tags, func(i, j int) bool {
+		return tags[i].quality > tags[j].quality
+	})
+
+	result := make([]string, 0, len(tags))
+	for _, t := range tags {
+		result = append(result, t.tag)
+	}
+	return result
+}
+
+func normalizeLocale(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+}
+
+func baseLanguage(locale string) string {
+	base, _, _ := strings.Cut(locale, "-")
+	return base
+}
+
+func newDefaultCatalog() *Catalog {
+	catalog := NewCatalog("en")
+	catalog.Register("zh", map[int]string{
+		OK:                    "成功",
+		ErrInvalidArgument:    "参数错误",
+		ErrFailedPrecondition: "前置条件不满足",
+		ErrOutOfRange:         "超出范围",
+		ErrUnauthenticated:    "身份无效",
+		ErrPermissionDenied:   "权限不足",
+		ErrNotFound:           "资源不存在",
+		ErrAborted:            "操作已中止",
+		ErrAlreadyExists:      "资源已存在",
+		ErrResourceExhausted:  "系统繁忙",
+		ErrCanceled:           "客户端取消请求",
+		ErrDataLoss:           "数据丢失",
+		ErrUnknown:            "未知错误",
+		ErrInternal:           "内部错误",
+		ErrNotImplemented:     "方法未实现",
+		ErrUnavailable:        "服务暂停",
+		ErrDeadlineExceeded:   "系统无法执行",
+	})
+	return catalog
+}
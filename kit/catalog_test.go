@@ -0,0 +1,179 @@
+package kit
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func newLocaleContext(header http.Header) *gin.Context {
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	ctx.Request, _ = http.NewRequest(http.MethodGet, "/", http.NoBody)
+	ctx.Request.Header = header
+	return ctx
+}
+
+func TestCatalog_Message(t *testing.T) {
+	catalog := NewCatalog("en")
+	catalog.Register("zh", map[int]string{ErrNotFound: "资源不存在"})
+	catalog.Register("zh-TW", map[int]string{ErrNotFound: "資源不存在"})
+	catalog.Register("en", map[int]string{40010: "Coupon expired"})
+
+	testCases := []struct {
+		name     string
+		locale   string
+		code     int
+		expected string
+		found    bool
+	}{
+		{"exact locale", "zh-TW", ErrNotFound, "資源不存在", true},
+		{"underscore locale", "zh_tw", ErrNotFound, "資源不存在", true},
+		{"base language", "zh-CN", ErrNotFound, "资源不存在", true},
+		{"default locale", "fr", 40010, "Coupon expired", true},
+		{"falls back to Messages", "zh", ErrInternal, Messages[ErrInternal], true},
+		{"unknown code", "zh", 12345, "", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			message, found := catalog.Message(tc.locale, tc.code)
+			assert.Equal(t, tc.expected, message)
+			assert.Equal(t, tc.found, found)
+		})
+	}
+}
+
+func TestCatalog_Negotiate(t *testing.T) {
+	catalog := NewCatalog("en")
+	catalog.Register("zh", map[int]string{})
+	catalog.Register("ja", map[int]string{})
+
+	testCases := []struct {
+		name     string
+		header   string
+		expected string
+	}{
+		{"no header", "", "en"},
+		{"exact match", "ja", "ja"},
+		{"base language match", "zh-CN,zh;q=0.9", "zh"},
+		{"quality order", "ja;q=0.5, zh;q=0.8, fr", "zh"},
+		{"unsupported", "fr, de;q=0.5", "en"},
+		{"zero quality is ignored", "ja;q=0, en;q=0.1", "en"},
+		{"invalid quality is ignored", "ja;q=abc, *", "en"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := newLocaleContext(http.Header{"Accept-Language": {tc.header}})
+			assert.Equal(t, tc.expected, catalog.Negotiate(ctx))
+		})
+	}
+
+	t.Run("gin value takes precedence", func(t *testing.T) {
+		ctx := newLocaleContext(http.Header{"Accept-Language": {"ja"}})
+		ctx.Set(LocaleKey, "zh")
+		assert.Equal(t, "zh", catalog.Negotiate(ctx))
+	})
+
+	t.Run("context value takes precedence", func(t *testing.T) {
+		ctx := newLocaleContext(http.Header{"Accept-Language": {"ja"}})
+		ctx.Request = ctx.Request.WithContext(ContextWithLocale(context.Background(), "zh"))
+		assert.Equal(t, "zh", catalog.Negotiate(ctx))
+	})
+}
+
+func TestTranslator_LocalizedInfo(t *testing.T) {
+	serve := func(handler HandlerFunc, acceptLanguage string) RespBody {
+		r := gin.New()
+		r.GET("/test", TranslateFunc(handler))
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/test", http.NoBody)
+		req.Header.Set("Accept-Language", acceptLanguage)
+		r.ServeHTTP(w, req)
+
+		respBody := RespBody{}
+		_ = json.Unmarshal(w.Body.Bytes(), &respBody)
+		return respBody
+	}
+
+	t.Run("default message is localized", func(t *testing.T) {
+		respBody := serve(func(ctx *gin.Context) (any, error) {
+			return nil, NewNotFoundError()
+		}, "zh-CN")
+		assert.Equal(t, "资源不存在", respBody.Info)
+	})
+
+	t.Run("english by default", func(t *testing.T) {
+		respBody := serve(func(ctx *gin.Context) (any, error) {
+			return nil, NewNotFoundError()
+		}, "")
+		assert.Equal(t, Messages[ErrNotFound], respBody.Info)
+	})
+
+	t.Run("custom info is kept", func(t *testing.T) {
+		respBody := serve(func(ctx *gin.Context) (any, error) {
+			return nil, NewNotFoundError().WithInfo("user missing")
+		}, "zh-CN")
+		assert.Equal(t, "user missing", respBody.Info)
+	})
+
+	t.Run("internal error is localized", func(t *testing.T) {
+		respBody := serve(func(ctx *gin.Context) (any, error) {
+			return nil, ErrCustom
+		}, "zh")
+		assert.Equal(t, "内部错误", respBody.Info)
+	})
+
+	t.Run("custom catalog", func(t *testing.T) {
+		catalog := NewCatalog("en")
+		catalog.Register("zh", map[int]string{40010: "优惠券已过期"})
+
+		w := serveTranslator(NewTranslator(WithCatalog(catalog)), func(ctx *gin.Context) (any, error) {
+			ctx.Set(LocaleKey, "zh")
+			return nil, NewException().WithCode(40010)
+		})
+
+		respBody := RespBody{}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &respBody))
+		assert.Equal(t, "优惠券已过期", respBody.Info)
+	})
+
+	t.Run("registered default message is localized", func(t *testing.T) {
+		catalog := NewCatalog("en")
+		catalog.Register("en", map[int]string{40010: "Coupon expired"})
+		catalog.Register("zh", map[int]string{40010: "优惠券已过期"})
+		translator := NewTranslator(WithCatalog(catalog))
+
+		for locale, info := range map[string]string{"zh": "优惠券已过期", "en": "Coupon expired"} {
+			w := serveTranslator(translator, func(ctx *gin.Context) (any, error) {
+				ctx.Set(LocaleKey, locale)
+				return nil, NewException().WithCode(40010).WithInfo("Coupon expired")
+			})
+
+			respBody := RespBody{}
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &respBody))
+			assert.Equal(t, info, respBody.Info)
+		}
+	})
+
+	t.Run("custom info of a registered code is kept", func(t *testing.T) {
+		catalog := NewCatalog("en")
+		catalog.Register("en", map[int]string{40010: "Coupon expired"})
+		catalog.Register("zh", map[int]string{40010: "优惠券已过期"})
+
+		w := serveTranslator(NewTranslator(WithCatalog(catalog)), func(ctx *gin.Context) (any, error) {
+			ctx.Set(LocaleKey, "zh")
+			return nil, NewException().WithCode(40010).WithInfo("Coupon SPRING expired yesterday")
+		})
+
+		respBody := RespBody{}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &respBody))
+		assert.Equal(t, "Coupon SPRING expired yesterday", respBody.Info)
+	})
+}
@@ -0,0 +1,105 @@
+package kit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// StepFunc is a step of a Chain, it should stop early once ctx is done.
+type StepFunc func(ctx context.Context) error
+
+// StepError reports the step of a chain that failed.
+type StepError struct {
+	Name  string // Name of the step, may be empty
+	Index int    // Position of the step in the chain, counting from 0
+	Err   error  // Error returned by the step, or the context error when the step was skipped
+}
+
+// Error implements the error interface.
+func (e *StepError) Error() string {
+	if e.Name == "" {
+		return fmt.Sprintf("step %d: %v", e.Index, e.Err)
+	}
+	return fmt.Sprintf("step %d (%s): %v", e.Index, e.Name, e.Err)
+}
+
+// Unwrap returns the error of the step, so a BusinessError it carries keeps its code in responses.
+func (e *StepError) Unwrap() error {
+	return e.Err
+}
+
+// StepTiming records the execution of a step.
+type StepTiming struct {
+	Name     string        // Name of the step
+	Index    int           // Position of the step in the chain
+	Duration time.Duration // Time spent in the step
+	Err      error         // Error returned by the step
+}
+
+// Chain is the context-aware Alice. It executes named steps sequentially, stops at the first
+// error, and skips the remaining steps once its context is done.
+type Chain struct {
+	ctx     context.Context
+	err     error
+	index   int
+	timings []StepTiming
+}
+
+// NewChain creates a chain whose steps run with ctx.
+func NewChain(ctx context.Context) *Chain {
+	return &Chain{ctx: ctx}
+}
+
+// Then runs a named step unless a previous step failed or the context is done.
+// A skipped step fails with an ErrCanceled or ErrDeadlineExceeded Exception.
+// Context errors returned by the step itself are converted the same way.
+func (c *Chain) Then(name string, step StepFunc) *Chain {
+	index := c.index
+	c.index++
+	if c.err != nil {
+		return c
+	}
+	if err := c.ctx.Err(); err != nil {
+		c.err = &StepError{Name: name, Index: index, Err: ContextError(err)}
+		return c
+	}
+
+	start := time.Now()
+	err := ContextError(step(c.ctx))
+	c.timings = append(c.timings, StepTiming{Name: name, Index: index, Duration: time.Since(start), Err: err})
+	if err != nil {
+		c.err = &StepError{Name: name, Index: index, Err: err}
+	}
+	return c
+}
+
+// Error returns the first error encountered in the chain as a *StepError, or nil if all succeeded.
+func (c *Chain) Error() error {
+	return c.err
+}
+
+// Timings returns the timing of the executed steps, in execution order.
+func (c *Chain) Timings() []StepTiming {
+	return append([]StepTiming(nil), c.timings...)
+}
+
+// ContextError converts context.Canceled and context.DeadlineExceeded into ErrCanceled and
+// ErrDeadlineExceeded Exceptions. Other errors, including business errors, are returned as is.
+func ContextError(err error) error {
+	if err == nil {
+		return nil
+	}
+	var ex BusinessError
+	if errors.As(err, &ex) {
+		return err
+	}
+	switch {
+	case errors.Is(err, context.Canceled):
+		return NewCanceledError().WithErr(err)
+	case errors.Is(err, context.DeadlineExceeded):
+		return NewDeadlineExceededError().WithErr(err)
+	}
+	return err
+}
@@ -0,0 +1,110 @@
+package kit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChain(t *testing.T) {
+	t.Run("all steps succeed", func(t *testing.T) {
+		var order []string
+		c := NewChain(context.Background()).
+			Then("load", func(ctx context.Context) error { order = append(order, "load"); return nil }).
+			Then("save", func(ctx context.Context) error { order = append(order, "save"); return nil })
+
+		assert.NoError(t, c.Error())
+		assert.Equal(t, []string{"load", "save"}, order)
+
+		timings := c.Timings()
+		assert.Len(t, timings, 2)
+		assert.Equal(t, "save", timings[1].Name)
+		assert.Equal(t, 1, timings[1].Index)
+	})
+
+	t.Run("failing step is reported", func(t *testing.T) {
+		failure := NewNotFoundError()
+		executed := false
+
+		c := NewChain(context.Background()).
+			Then("load", func(ctx context.Context) error { return nil }).
+			Then("check", func(ctx context.Context) error { return failure }).
+			Then("save", func(ctx context.Context) error { executed = true; return nil })
+
+		var stepErr *StepError
+		assert.True(t, errors.As(c.Error(), &stepErr))
+		assert.Equal(t, "check", stepErr.Name)
+		assert.Equal(t, 1, stepErr.Index)
+		assert.ErrorIs(t, c.Error(), failure)
+		assert.Equal(t, "step 1 (check): Resource does not exist", c.Error().Error())
+		assert.False(t, executed)
+
+		timings := c.Timings()
+		assert.Len(t, timings, 2)
+		assert.Same(t, failure, timings[1].Err)
+	})
+
+	t.Run("unnamed step", func(t *testing.T) {
+		err := NewChain(context.Background()).Then("", func(ctx context.Context) error { return errors.New("boom") }).Error()
+		assert.EqualError(t, err, "step 0: boom")
+	})
+
+	t.Run("canceled context skips the remaining steps", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		executed := false
+
+		c := NewChain(ctx).
+			Then("first", func(ctx context.Context) error { cancel(); return nil }).
+			Then("second", func(ctx context.Context) error { executed = true; return nil }).
+			Then("third", func(ctx context.Context) error { executed = true; return nil })
+
+		var stepErr *StepError
+		assert.True(t, errors.As(c.Error(), &stepErr))
+		assert.Equal(t, "second", stepErr.Name)
+		assert.Equal(t, 1, stepErr.Index)
+		assert.ErrorIs(t, c.Error(), NewCanceledError())
+		assert.ErrorIs(t, c.Error(), context.Canceled)
+		assert.False(t, executed)
+		assert.Len(t, c.Timings(), 1)
+	})
+
+	t.Run("deadline exceeded", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+		defer cancel()
+
+		err := NewChain(ctx).
+			Then("slow", func(ctx context.Context) error {
+				<-ctx.Done()
+				return ctx.Err()
+			}).
+			Error()
+
+		assert.ErrorIs(t, err, NewDeadlineExceededError())
+		assert.Equal(t, "step 0 (slow): context deadline exceeded", err.Error())
+	})
+
+	t.Run("timing", func(t *testing.T) {
+		c := NewChain(context.Background()).Then("sleep", func(ctx context.Context) error {
+			time.Sleep(5 * time.Millisecond)
+			return nil
+		})
+		assert.GreaterOrEqual(t, c.Timings()[0].Duration, 5*time.Millisecond)
+	})
+}
+
+func TestContextError(t *testing.T) {
+	assert.NoError(t, ContextError(nil))
+
+	plain := errors.New("plain")
+	assert.Same(t, plain, ContextError(plain))
+
+	business := NewAbortedError().WithErr(context.Canceled)
+	assert.Same(t, business, ContextError(business))
+
+	assert.ErrorIs(t, ContextError(fmt.Errorf("query: %w", context.Canceled)), NewCanceledError())
+	assert.ErrorIs(t, ContextError(context.DeadlineExceeded), NewDeadlineExceededError())
+}
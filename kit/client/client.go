@@ -0,0 +1,324 @@
+// Package client calls kit style APIs from Go, decoding the RespBody envelope
+// and turning failed responses back into *kit.Exception values.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+
+	"github.com/qxsugar/pkg/kit"
+)
+
+// Client sends requests to a kit API.
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	header     http.Header
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithHTTPClient sets the HTTP client used to send requests, http.DefaultClient by default.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithHeader adds a header sent with every request, such as Authorization or Accept-Language.
+func WithHeader(key, value string) Option {
+	return func(c *Client) {
+		c.header.Add(key, value)
+	}
+}
+
+// New creates a client for the API served at baseURL.
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		httpClient: http.DefaultClient,
+		header:     http.Header{},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Request describes a call to a route.
+type Request struct {
+	Method string      // HTTP method
+	Path   string      // Path relative to the base URL, with its parameters filled in
+	Query  url.Values  // Query parameters
+	Header http.Header // Request headers
+	Body   any         // Value sent as the JSON body, no body is sent when nil
+}
+
+// Params fills the path parameters of routes that have no request type.
+type Params map[string]string
+
+// Page is the typed counterpart of kit.PageBody.
+type Page[T any] struct {
+	Offset int   `json:"offset"` // Offset
+	Limit  int   `json:"limit"`  // Limit on the number of items
+	Total  int64 `json:"total"`  // Total number of items
+	List   []T   `json:"list"`   // Data list
+}
+
+// envelope mirrors kit.RespBody, keeping resp_data raw until its type is known.
+type envelope struct {
+	Succeeded *bool           `json:"succeeded"`
+	RespData  json.RawMessage `json:"resp_data"`
+	Code      int             `json:"code"`
+	Info      string          `json:"info"`
+	Desc      string          `json:"desc"`
+	Details   kit.Details     `json:"details"`
+}
+
+// Do sends the request and decodes resp_data into T.
+// A response that did not succeed is returned as a *kit.Exception carrying its code, info, desc and details,
+// so callers can match it with errors.Is just like on the server side.
+func Do[T any](ctx context.Context, c *Client, req Request) (T, error) {
+	var data T
+
+	env, err := c.do(ctx, req)
+	if err != nil || env == nil {
+		return data, err
+	}
+	if len(env.RespData) == 0 || bytes.Equal(env.RespData, []byte("null")) {
+		return data, nil
+	}
+	if err := json.Unmarshal(env.RespData, &data); err != nil {
+		return data, fmt.Errorf("client: decode resp_data of %s %s: %w", req.Method, req.Path, err)
+	}
+	return data, nil
+}
+
+// DoPage sends the request and decodes a kit.PageBody response whose items are T.
+func DoPage[T any](ctx context.Context, c *Client, req Request) (Page[T], error) {
+	return Do[Page[T]](ctx, c, req)
+}
+
+// do sends the request and returns its envelope,
+// which is nil for successful responses without a body, such as HEAD responses.
+func (c *Client) do(ctx context.Context, req Request) (*envelope, error) {
+	httpReq, err := c.newHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("client: read response of %s %s: %w", req.Method, req.Path, err)
+	}
+
+	var env envelope
+	if err := json.Unmarshal(data, &env); err != nil || env.Succeeded == nil {
+		if resp.StatusCode < http.StatusBadRequest && len(bytes.TrimSpace(data)) == 0 {
+			return nil, nil
+		}
+		return nil, unexpectedResponse(resp.StatusCode, resp.Status)
+	}
+	if !*env.Succeeded {
+		return nil, kit.NewException().
+			WithCode(env.Code).
+			WithInfo(env.Info).
+			WithDesc(env.Desc).
+			WithDetails(env.Details...)
+	}
+	return &env, nil
+}
+
+func (c *Client) newHTTPRequest(ctx context.Context, req Request) (*http.Request, error) {
+	target := c.baseURL + req.Path
+	if len(req.Query) > 0 {
+		target += "?" + req.Query.Encode()
+	}
+
+	var body io.Reader
+	if req.Body != nil {
+		data, err := json.Marshal(req.Body)
+		if err != nil {
+			return nil, fmt.Errorf("client: encode body of %s %s: %w", req.Method, req.Path, err)
+		}
+		body = bytes.NewReader(data)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, req.Method, target, body)
+	if err != nil {
+		return nil, err
+	}
+	for key, values := range c.header {
+		httpReq.Header[key] = append([]string(nil), values...)
+	}
+	for key, values := range req.Header {
+		httpReq.Header[key] = append([]string(nil), values...)
+	}
+	httpReq.Header.Set("Accept", "application/json")
+	if body != nil {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+	return httpReq, nil
+}
+
+// unexpectedResponse converts a response that is not a RespBody, such as the plain text
+// 404 of the gin router, into an exception whose code is derived from the HTTP status.
+func unexpectedResponse(statusCode int, status string) *kit.Exception {
+	code := kit.ErrUnknown
+	if _, ok := kit.DefaultCodeRegistry.Lookup(statusCode * 100); ok && statusCode >= http.StatusBadRequest {
+		code = statusCode * 100
+	}
+	return kit.NewException().
+		WithCode(code).
+		WithInfo(kit.Messages[code]).
+		WithDesc("unexpected response: " + status)
+}
+
+// NewRequest builds the request of a route the way kit.BindRequest reads it:
+// uri tagged fields fill the path parameters of route, form tagged fields become query parameters,
+// header tagged fields become headers, and req is sent as the JSON body for methods that have one.
+// Zero query and header values are omitted. A Params req only fills the path parameters.
+func NewRequest(method, route string, req any) (Request, error) {
+	r := Request{
+		Method: method,
+		Query:  url.Values{},
+		Header: http.Header{},
+	}
+
+	params := map[string]string{}
+	if p, ok := req.(Params); ok {
+		params = p
+	} else if req != nil {
+		v := reflect.ValueOf(req)
+		for v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				break
+			}
+			v = v.Elem()
+		}
+		if v.Kind() == reflect.Struct {
+			collectFields(v, params, &r)
+		}
+		if hasBody(method) {
+			r.Body = req
+		}
+	}
+
+	path, err := fillPath(route, params)
+	if err != nil {
+		return Request{}, err
+	}
+	r.Path = path
+	return r, nil
+}
+
+func collectFields(v reflect.Value, params map[string]string, r *Request) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		value := v.Field(i)
+		if field.Anonymous && value.Kind() == reflect.Struct {
+			collectFields(value, params, r)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		if name := tagName(field, "uri"); name != "" {
+			if s, ok := formatValues(value); ok && len(s) > 0 {
+				params[name] = s[0]
+			}
+		}
+		if name := tagName(field, "form"); name != "" && !value.IsZero() {
+			if s, ok := formatValues(value); ok {
+				r.Query[name] = s
+			}
+		}
+		if name := tagName(field, "header"); name != "" && !value.IsZero() {
+			if s, ok := formatValues(value); ok {
+				r.Header[http.CanonicalHeaderKey(name)] = s
+			}
+		}
+	}
+}
+
+func tagName(field reflect.StructField, key string) string {
+	name, _, _ := strings.Cut(field.Tag.Get(key), ",")
+	if name == "-" {
+		return ""
+	}
+	return name
+}
+
+// formatValues formats a scalar, or each element of a slice, the way gin parses them back.
+func formatValues(v reflect.Value) ([]string, bool) {
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil, false
+		}
+		v = v.Elem()
+	}
+	if v.Kind() == reflect.Slice || v.Kind() == reflect.Array {
+		values := make([]string, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			s, ok := formatValues(v.Index(i))
+			if !ok {
+				return nil, false
+			}
+			values = append(values, s...)
+		}
+		return values, true
+	}
+	if marshaler, ok := v.Interface().(encoding.TextMarshaler); ok {
+		text, err := marshaler.MarshalText()
+		if err != nil {
+			return nil, false
+		}
+		return []string{string(text)}, true
+	}
+	return []string{fmt.Sprint(v.Interface())}, true
+}
+
+// fillPath replaces the :name and *name parameters of a gin route.
+func fillPath(route string, params map[string]string) (string, error) {
+	segments := strings.Split(route, "/")
+	for i, segment := range segments {
+		if segment == "" || (segment[0] != ':' && segment[0] != '*') {
+			continue
+		}
+		value, ok := params[segment[1:]]
+		if !ok {
+			return "", fmt.Errorf("client: missing path parameter %q of %s", segment[1:], route)
+		}
+		if segment[0] == '*' {
+			segments[i] = strings.TrimPrefix(value, "/")
+		} else {
+			segments[i] = url.PathEscape(value)
+		}
+	}
+	return strings.Join(segments, "/"), nil
+}
+
+func hasBody(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodDelete, http.MethodOptions, http.MethodTrace:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,180 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/qxsugar/pkg/kit"
+)
+
+type item struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func newServer(t *testing.T, register func(api *kit.RouterGroup)) *httptest.Server {
+	t.Helper()
+	gin.SetMode(gin.DebugMode)
+	engine := gin.New()
+	register(kit.NewRouterGroup(engine.Group("/api")))
+	server := httptest.NewServer(engine)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestDo(t *testing.T) {
+	server := newServer(t, func(api *kit.RouterGroup) {
+		api.GET("/item", func(ctx *gin.Context) (any, error) {
+			return item{ID: 1, Name: ctx.GetHeader("Accept-Language")}, nil
+		})
+		api.GET("/empty", func(ctx *gin.Context) (any, error) {
+			return nil, nil
+		})
+		api.GET("/missing", func(ctx *gin.Context) (any, error) {
+			return nil, kit.NewNotFoundError().
+				WithInfo("item missing").
+				WithErr(errors.New("no rows")).
+				WithDetails(kit.ErrorInfo{Reason: "ITEM_MISSING", Domain: "test"})
+		})
+		api.GET("/panic", func(ctx *gin.Context) (any, error) {
+			panic("boom")
+		})
+		api.GET("/page", func(ctx *gin.Context) (any, error) {
+			return kit.PageBody{Offset: 1, Limit: 2, Total: 3, List: []item{{ID: 2}, {ID: 3}}}, nil
+		})
+	})
+	c := New(server.URL+"/", WithHeader("Accept-Language", "zh"))
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		data, err := Do[item](ctx, c, Request{Method: http.MethodGet, Path: "/api/item"})
+		assert.NoError(t, err)
+		assert.Equal(t, item{ID: 1, Name: "zh"}, data)
+	})
+
+	t.Run("NullData", func(t *testing.T) {
+		data, err := Do[*item](ctx, c, Request{Method: http.MethodGet, Path: "/api/empty"})
+		assert.NoError(t, err)
+		assert.Nil(t, data)
+	})
+
+	t.Run("BusinessError", func(t *testing.T) {
+		_, err := Do[item](ctx, c, Request{Method: http.MethodGet, Path: "/api/missing"})
+		assert.ErrorIs(t, err, kit.NewNotFoundError())
+
+		var ex *kit.Exception
+		assert.True(t, errors.As(err, &ex))
+		assert.Equal(t, kit.ErrNotFound, ex.Code())
+		assert.Equal(t, "item missing", ex.Info())
+		assert.Equal(t, "no rows", ex.Desc())
+		assert.Equal(t, []kit.Detail{kit.ErrorInfo{Reason: "ITEM_MISSING", Domain: "test"}}, ex.Details())
+	})
+
+	t.Run("Panic", func(t *testing.T) {
+		_, err := Do[item](ctx, c, Request{Method: http.MethodGet, Path: "/api/panic"})
+		assert.ErrorIs(t, err, kit.NewInternalError())
+		assert.Equal(t, "内部错误", err.(*kit.Exception).Info())
+	})
+
+	t.Run("NotAnEnvelope", func(t *testing.T) {
+		_, err := Do[item](ctx, c, Request{Method: http.MethodGet, Path: "/api/unknown"})
+		assert.ErrorIs(t, err, kit.NewNotFoundError())
+		assert.Equal(t, "unexpected response: 404 Not Found", err.(*kit.Exception).Desc())
+	})
+
+	t.Run("Page", func(t *testing.T) {
+		page, err := DoPage[item](ctx, c, Request{Method: http.MethodGet, Path: "/api/page"})
+		assert.NoError(t, err)
+		assert.Equal(t, Page[item]{Offset: 1, Limit: 2, Total: 3, List: []item{{ID: 2}, {ID: 3}}}, page)
+	})
+
+	t.Run("DecodeError", func(t *testing.T) {
+		_, err := Do[int](ctx, c, Request{Method: http.MethodGet, Path: "/api/item"})
+		assert.ErrorContains(t, err, "decode resp_data of GET /api/item")
+	})
+
+	t.Run("Canceled", func(t *testing.T) {
+		canceled, cancel := context.WithCancel(ctx)
+		cancel()
+		_, err := Do[item](canceled, c, Request{Method: http.MethodGet, Path: "/api/item"})
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestDo_Head(t *testing.T) {
+	server := newServer(t, func(api *kit.RouterGroup) {
+		api.HEAD("/item", func(ctx *gin.Context) (any, error) {
+			return item{ID: 1}, nil
+		})
+	})
+
+	data, err := Do[item](context.Background(), New(server.URL), Request{Method: http.MethodHead, Path: "/api/item"})
+	assert.NoError(t, err)
+	assert.Equal(t, item{}, data)
+}
+
+func TestDo_UnknownStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "bad gateway", http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	_, err := Do[item](context.Background(), New(server.URL, WithHTTPClient(server.Client())), Request{Method: http.MethodGet, Path: "/"})
+	assert.ErrorIs(t, err, kit.NewUnknownError())
+	assert.Equal(t, "unexpected response: 502 Bad Gateway", err.(*kit.Exception).Desc())
+}
+
+func TestNewRequest(t *testing.T) {
+	type embedded struct {
+		Tenant string `header:"x-tenant"`
+	}
+	type request struct {
+		embedded
+		ID     int      `uri:"id" json:"-"`
+		File   string   `uri:"file" json:"-"`
+		Tags   []string `form:"tags" json:"-"`
+		Limit  int      `form:"limit" json:"-"`
+		Name   string   `json:"name"`
+		hidden string
+	}
+
+	req := &request{embedded: embedded{Tenant: "acme"}, ID: 7, File: "/a b/c.txt", Tags: []string{"x", "y"}, Name: "n", hidden: "h"}
+
+	t.Run("Body", func(t *testing.T) {
+		r, err := NewRequest(http.MethodPut, "/items/:id/*file", req)
+		assert.NoError(t, err)
+		assert.Equal(t, Request{
+			Method: http.MethodPut,
+			Path:   "/items/7/a b/c.txt",
+			Query:  url.Values{"tags": {"x", "y"}},
+			Header: http.Header{"X-Tenant": {"acme"}},
+			Body:   req,
+		}, r)
+	})
+
+	t.Run("NoBody", func(t *testing.T) {
+		r, err := NewRequest(http.MethodGet, "/items/:id", req)
+		assert.NoError(t, err)
+		assert.Equal(t, "/items/7", r.Path)
+		assert.Nil(t, r.Body)
+	})
+
+	t.Run("Params", func(t *testing.T) {
+		r, err := NewRequest(http.MethodDelete, "/items/:id", Params{"id": "a/b"})
+		assert.NoError(t, err)
+		assert.Equal(t, "/items/a%2Fb", r.Path)
+		assert.Nil(t, r.Body)
+	})
+
+	t.Run("MissingParam", func(t *testing.T) {
+		_, err := NewRequest(http.MethodDelete, "/items/:id", nil)
+		assert.EqualError(t, err, `client: missing path parameter "id" of /items/:id`)
+	})
+}
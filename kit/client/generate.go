@@ -0,0 +1,321 @@
+package client
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"io"
+	"path"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/qxsugar/pkg/kit"
+)
+
+const clientImportPath = "github.com/qxsugar/pkg/kit/client"
+
+var generatedTemplate = template.Must(template.New("client").Parse(`// Code generated by github.com/qxsugar/pkg/kit/client. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+{{- range .Imports}}
+	{{.}}
+{{- end}}
+)
+
+// Client calls the routes of the API.
+type Client struct {
+	*client.Client
+}
+
+// New wraps c into a typed Client.
+func New(c *client.Client) *Client {
+	return &Client{Client: c}
+}
+{{range .Methods}}
+// {{.Name}} calls {{.Method}} {{.Path}}.
+{{- if .Summary}}
+//
+// {{.Summary}}
+{{- end}}
+func (c *Client) {{.Name}}(ctx context.Context
+{{- if .Request}}, req {{.Request}}{{end}}
+{{- range .Params}}, {{.Arg}} string{{end}}) ({{.Response}}, error) {
+	r, err := client.NewRequest({{printf "%q" .Method}}, {{printf "%q" .Path}},
+	{{- if .Request}} req
+	{{- else if .Params}} client.Params{ {{- range .Params}}{{printf "%q" .Name}}: {{.Arg}}, {{end -}} }
+	{{- else}} nil{{end}})
+	if err != nil {
+		var zero {{.Response}}
+		return zero, err
+	}
+	return {{.Call}}(ctx, c.Client, r)
+}
+{{end}}`))
+
+type generatedFile struct {
+	Package string
+	Imports []string
+	Methods []generatedMethod
+}
+
+type generatedMethod struct {
+	Name     string
+	Method   string
+	Path     string
+	Summary  string
+	Request  string
+	Params   []generatedParam // Path parameters, passed as arguments when there is no request type
+	Response string
+	Call     string
+}
+
+type generatedParam struct {
+	Name string
+	Arg  string
+}
+
+// Generate writes the Go source of a typed client for routes, usually kit.RouterGroup.Routes,
+// into a package named pkg. Each route becomes a method named after its operation ID,
+// taking a pointer to the RouteInfo.Request type and returning the RouteInfo.Response type.
+// A kit.PageBodyOf response is returned as a Page.
+//
+// The request and response types must be declared in importable packages, so Generate is
+// typically called from a small program run by go:generate that registers the routes:
+//
+//	//go:generate go run ./internal/clientgen -o client.gen.go
+func Generate(w io.Writer, pkg string, routes []kit.RouteInfo) error {
+	g := &generator{
+		imports: map[string]string{clientImportPath: "client"},
+		aliases: map[string]bool{"client": true, "context": true},
+	}
+
+	file := generatedFile{Package: pkg}
+	names := map[string]string{}
+	for _, route := range routes {
+		method, err := g.method(route)
+		if err != nil {
+			return err
+		}
+		key := route.Method + " " + route.Path
+		if previous, ok := names[method.Name]; ok {
+			return fmt.Errorf("client: %s and %s share the method name %s, set distinct RouteDoc.OperationID", previous, key, method.Name)
+		}
+		names[method.Name] = key
+		file.Methods = append(file.Methods, method)
+	}
+
+	file.Imports = append(file.Imports, strconv.Quote("context"), "")
+	for _, pkgPath := range g.sortedImports() {
+		alias := g.imports[pkgPath]
+		if alias == path.Base(pkgPath) {
+			file.Imports = append(file.Imports, strconv.Quote(pkgPath))
+		} else {
+			file.Imports = append(file.Imports, alias+" "+strconv.Quote(pkgPath))
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := generatedTemplate.Execute(&buf, file); err != nil {
+		return err
+	}
+	source, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("client: format generated source: %w", err)
+	}
+	_, err = w.Write(source)
+	return err
+}
+
+type generator struct {
+	imports map[string]string // Package path to alias
+	aliases map[string]bool   // Aliases in use
+}
+
+func (g *generator) method(route kit.RouteInfo) (generatedMethod, error) {
+	name := exportedName(route.OperationID())
+	if !token.IsIdentifier(name) {
+		return generatedMethod{}, fmt.Errorf("client: operation ID %q of %s %s is not a valid method name",
+			route.OperationID(), route.Method, route.Path)
+	}
+
+	m := generatedMethod{
+		Name:     name,
+		Method:   route.Method,
+		Path:     route.Path,
+		Summary:  strings.Join(strings.Fields(route.Doc.Summary), " "),
+		Response: "any",
+		Call:     "client.Do[any]",
+	}
+
+	if route.Request != nil {
+		request, err := g.typeExpr(route.Request)
+		if err != nil {
+			return generatedMethod{}, err
+		}
+		if !strings.HasPrefix(request, "*") {
+			request = "*" + request
+		}
+		m.Request = request
+	} else {
+		m.Params = pathParams(route.Path)
+	}
+
+	if route.Response == nil {
+		return m, nil
+	}
+	if item, ok := pageItem(route.Response); ok {
+		expr, err := g.typeExpr(item)
+		if err != nil {
+			return generatedMethod{}, err
+		}
+		m.Response = "client.Page[" + expr + "]"
+		m.Call = "client.DoPage[" + expr + "]"
+		return m, nil
+	}
+	expr, err := g.typeExpr(route.Response)
+	if err != nil {
+		return generatedMethod{}, err
+	}
+	m.Response = expr
+	m.Call = "client.Do[" + expr + "]"
+	return m, nil
+}
+
+var pageBodyType = reflect.TypeFor[kit.PageBody]()
+
+// pageItem returns the item type of a kit.PageBody or kit.PageBodyOf response, any for PageBody.
+func pageItem(t reflect.Type) (reflect.Type, bool) {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t != pageBodyType && (t.PkgPath() != pageBodyType.PkgPath() || !strings.HasPrefix(t.Name(), "PageBodyOf[")) {
+		return nil, false
+	}
+	list, _ := t.FieldByName("List")
+	if list.Type.Kind() == reflect.Slice {
+		return list.Type.Elem(), true
+	}
+	return list.Type, true
+}
+
+// typeExpr returns the Go expression of t, importing the packages it refers to.
+func (g *generator) typeExpr(t reflect.Type) (string, error) {
+	if t.Name() != "" {
+		if t.PkgPath() == "" {
+			return t.Name(), nil
+		}
+		if t.PkgPath() == "main" || strings.Contains(t.Name(), "[") {
+			return "", fmt.Errorf("client: type %s cannot be referenced from generated code", t)
+		}
+		return g.qualifier(t.PkgPath()) + "." + t.Name(), nil
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Slice:
+		elem, err := g.typeExpr(t.Elem())
+		if err != nil {
+			return "", err
+		}
+		if t.Kind() == reflect.Pointer {
+			return "*" + elem, nil
+		}
+		return "[]" + elem, nil
+	case reflect.Array:
+		elem, err := g.typeExpr(t.Elem())
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("[%d]%s", t.Len(), elem), nil
+	case reflect.Map:
+		key, err := g.typeExpr(t.Key())
+		if err != nil {
+			return "", err
+		}
+		elem, err := g.typeExpr(t.Elem())
+		if err != nil {
+			return "", err
+		}
+		return "map[" + key + "]" + elem, nil
+	case reflect.Interface:
+		if t.NumMethod() == 0 {
+			return "any", nil
+		}
+	}
+	return "", fmt.Errorf("client: unnamed type %s is not supported, declare a named type", t)
+}
+
+func (g *generator) qualifier(pkgPath string) string {
+	if alias, ok := g.imports[pkgPath]; ok {
+		return alias
+	}
+
+	base := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+			return r
+		}
+		return -1
+	}, path.Base(pkgPath))
+	if base == "" || !token.IsIdentifier(base) {
+		base = "pkg"
+	}
+
+	alias := base
+	for i := 2; g.aliases[alias]; i++ {
+		alias = base + strconv.Itoa(i)
+	}
+	g.aliases[alias] = true
+	g.imports[pkgPath] = alias
+	return alias
+}
+
+func (g *generator) sortedImports() []string {
+	paths := make([]string, 0, len(g.imports))
+	for pkgPath := range g.imports {
+		paths = append(paths, pkgPath)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// pathParams returns the :name and *name parameters of a gin route.
+func pathParams(route string) []generatedParam {
+	var params []generatedParam
+	for _, segment := range strings.Split(route, "/") {
+		if segment == "" || (segment[0] != ':' && segment[0] != '*') {
+			continue
+		}
+		name := segment[1:]
+		arg := []rune(exportedName(name))
+		if len(arg) > 0 {
+			arg[0] = unicode.ToLower(arg[0])
+		}
+		// Keep clear of the identifiers used by the generated method body.
+		argName := string(arg) + "Param"
+		if token.IsIdentifier(string(arg)) && !token.IsKeyword(string(arg)) && !generatedIdents[string(arg)] {
+			argName = string(arg)
+		}
+		params = append(params, generatedParam{Name: name, Arg: argName})
+	}
+	return params
+}
+
+var generatedIdents = map[string]bool{"c": true, "ctx": true, "r": true, "err": true, "zero": true, "client": true, "context": true}
+
+// exportedName turns an operation ID such as getUser or get-user into GetUser.
+func exportedName(operationID string) string {
+	var b strings.Builder
+	for _, word := range strings.FieldsFunc(operationID, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		runes := []rune(word)
+		b.WriteString(string(unicode.ToUpper(runes[0])) + string(runes[1:]))
+	}
+	return b.String()
+}
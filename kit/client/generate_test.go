@@ -0,0 +1,103 @@
+package client_test
+
+import (
+	"bytes"
+	"context"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/qxsugar/pkg/kit"
+	"github.com/qxsugar/pkg/kit/client"
+	"github.com/qxsugar/pkg/kit/client/internal/testapi"
+	"github.com/qxsugar/pkg/kit/client/internal/testapi/testclient"
+)
+
+func newTestAPI() (*gin.Engine, *kit.RouterGroup) {
+	engine := gin.New()
+	api := kit.NewRouterGroup(engine.Group("/api"))
+	testapi.Register(api)
+	return engine, api
+}
+
+func TestGenerate_UpToDate(t *testing.T) {
+	_, api := newTestAPI()
+
+	var buf bytes.Buffer
+	assert.NoError(t, client.Generate(&buf, "testclient", api.Routes()))
+
+	generated, err := os.ReadFile("internal/testapi/testclient/client.gen.go")
+	assert.NoError(t, err)
+	assert.Equal(t, string(generated), buf.String(), "run go generate ./kit/client/...")
+}
+
+func TestGenerate_Errors(t *testing.T) {
+	testCases := []struct {
+		name   string
+		routes []kit.RouteInfo
+		err    string
+	}{
+		{
+			name: "DuplicateName",
+			routes: []kit.RouteInfo{
+				{Method: "GET", Path: "/a", Doc: kit.RouteDoc{OperationID: "get"}},
+				{Method: "HEAD", Path: "/a", Doc: kit.RouteDoc{OperationID: "get"}},
+			},
+			err: "client: GET /a and HEAD /a share the method name Get, set distinct RouteDoc.OperationID",
+		},
+		{
+			name:   "InvalidName",
+			routes: []kit.RouteInfo{{Method: "GET", Path: "/a", Doc: kit.RouteDoc{OperationID: "1a"}}},
+			err:    `client: operation ID "1a" of GET /a is not a valid method name`,
+		},
+		{
+			name:   "UnnamedType",
+			routes: []kit.RouteInfo{{Method: "GET", Path: "/a", Response: reflect.TypeFor[struct{ A int }]()}},
+			err:    "client: unnamed type struct { A int } is not supported, declare a named type",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.EqualError(t, client.Generate(&bytes.Buffer{}, "api", tc.routes), tc.err)
+		})
+	}
+}
+
+func TestGeneratedClient(t *testing.T) {
+	engine, _ := newTestAPI()
+	server := httptest.NewServer(engine)
+	defer server.Close()
+
+	ctx := context.Background()
+	c := testclient.New(client.New(server.URL))
+
+	user, err := c.GetUser(ctx, &testapi.GetUserReq{ID: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, testapi.User{ID: 2, Name: "bob"}, user)
+
+	_, err = c.GetUser(ctx, &testapi.GetUserReq{ID: 9})
+	assert.ErrorIs(t, err, kit.NewNotFoundError())
+
+	page, err := c.ListUsers(ctx, &testapi.ListUsersReq{Offset: 1, Limit: 1, Tenant: "acme"})
+	assert.NoError(t, err)
+	assert.Equal(t, client.Page[testapi.User]{Offset: 1, Limit: 1, Total: 3, List: []testapi.User{{ID: 2, Name: "bob"}}}, page)
+
+	_, err = c.ListUsers(ctx, &testapi.ListUsersReq{})
+	assert.ErrorIs(t, err, kit.NewPermissionDeniedError())
+
+	created, err := c.CreateUser(ctx, &testapi.CreateUserReq{Name: "dave"})
+	assert.NoError(t, err)
+	assert.Equal(t, &testapi.User{ID: 4, Name: "dave"}, created)
+
+	_, err = c.CreateUser(ctx, &testapi.CreateUserReq{})
+	assert.ErrorIs(t, err, kit.NewInvalidArgumentError())
+
+	deleted, err := c.DeleteApiUsersId(ctx, "1")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"rows": float64(1)}, deleted)
+}
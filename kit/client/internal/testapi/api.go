@@ -0,0 +1,71 @@
+// Package testapi is a small kit API used to test the generated client.
+package testapi
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/qxsugar/pkg/kit"
+)
+
+// User is a user of the API.
+type User struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// GetUserReq selects a user.
+type GetUserReq struct {
+	ID int `uri:"id" binding:"required"`
+}
+
+// ListUsersReq pages through users.
+type ListUsersReq struct {
+	Offset int    `form:"offset"`
+	Limit  int    `form:"limit"`
+	Tenant string `header:"X-Tenant"`
+}
+
+// CreateUserReq creates a user.
+type CreateUserReq struct {
+	Name string `json:"name" binding:"required"`
+}
+
+// Users are the users served by Register.
+var Users = []User{{ID: 1, Name: "alice"}, {ID: 2, Name: "bob"}, {ID: 3, Name: "carol"}}
+
+// Register registers the routes of the API.
+func Register(api *kit.RouterGroup) {
+	api.Doc(kit.RouteDoc{
+		OperationID: "getUser",
+		Summary:     "Get a user",
+		Codes:       []int{kit.ErrNotFound},
+	}).GET("/users/:id", kit.Handle(func(ctx *gin.Context, req *GetUserReq) (User, error) {
+		for _, user := range Users {
+			if user.ID == req.ID {
+				return user, nil
+			}
+		}
+		return User{}, kit.NewNotFoundError().WithInfo("user missing")
+	}))
+
+	api.Doc(kit.RouteDoc{
+		OperationID: "listUsers",
+	}).GET("/users", kit.Handle(func(ctx *gin.Context, req *ListUsersReq) (kit.PageBodyOf[User], error) {
+		if req.Tenant == "" {
+			return kit.PageBodyOf[User]{}, kit.NewPermissionDeniedError()
+		}
+		start := min(req.Offset, len(Users))
+		end := min(start+req.Limit, len(Users))
+		return kit.PageBodyOf[User]{Offset: req.Offset, Limit: req.Limit, Total: int64(len(Users)), List: Users[start:end]}, nil
+	}))
+
+	api.Doc(kit.RouteDoc{
+		OperationID: "createUser",
+	}).POST("/users", kit.Handle(func(ctx *gin.Context, req *CreateUserReq) (*User, error) {
+		return &User{ID: len(Users) + 1, Name: req.Name}, nil
+	}))
+
+	api.DELETE("/users/:id", func(ctx *gin.Context) (any, error) {
+		return kit.RowAffectedBody{Rows: 1}, nil
+	})
+}
@@ -0,0 +1,32 @@
+// Command gen generates the typed client of testapi.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"log"
+	"os"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/qxsugar/pkg/kit"
+	"github.com/qxsugar/pkg/kit/client"
+	"github.com/qxsugar/pkg/kit/client/internal/testapi"
+)
+
+func main() {
+	output := flag.String("o", "client.gen.go", "output file")
+	flag.Parse()
+
+	gin.SetMode(gin.ReleaseMode)
+	api := kit.NewRouterGroup(gin.New().Group("/api"))
+	testapi.Register(api)
+
+	var buf bytes.Buffer
+	if err := client.Generate(&buf, "testclient", api.Routes()); err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*output, buf.Bytes(), 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
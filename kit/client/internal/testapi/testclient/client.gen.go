@@ -0,0 +1,62 @@
+// Code generated by github.com/qxsugar/pkg/kit/client. DO NOT EDIT.
+
+package testclient
+
+import (
+	"context"
+
+	"github.com/qxsugar/pkg/kit/client"
+	"github.com/qxsugar/pkg/kit/client/internal/testapi"
+)
+
+// Client calls the routes of the API.
+type Client struct {
+	*client.Client
+}
+
+// New wraps c into a typed Client.
+func New(c *client.Client) *Client {
+	return &Client{Client: c}
+}
+
+// GetUser calls GET /api/users/:id.
+//
+// Get a user
+func (c *Client) GetUser(ctx context.Context, req *testapi.GetUserReq) (testapi.User, error) {
+	r, err := client.NewRequest("GET", "/api/users/:id", req)
+	if err != nil {
+		var zero testapi.User
+		return zero, err
+	}
+	return client.Do[testapi.User](ctx, c.Client, r)
+}
+
+// ListUsers calls GET /api/users.
+func (c *Client) ListUsers(ctx context.Context, req *testapi.ListUsersReq) (client.Page[testapi.User], error) {
+	r, err := client.NewRequest("GET", "/api/users", req)
+	if err != nil {
+		var zero client.Page[testapi.User]
+		return zero, err
+	}
+	return client.DoPage[testapi.User](ctx, c.Client, r)
+}
+
+// CreateUser calls POST /api/users.
+func (c *Client) CreateUser(ctx context.Context, req *testapi.CreateUserReq) (*testapi.User, error) {
+	r, err := client.NewRequest("POST", "/api/users", req)
+	if err != nil {
+		var zero *testapi.User
+		return zero, err
+	}
+	return client.Do[*testapi.User](ctx, c.Client, r)
+}
+
+// DeleteApiUsersId calls DELETE /api/users/:id.
+func (c *Client) DeleteApiUsersId(ctx context.Context, id string) (any, error) {
+	r, err := client.NewRequest("DELETE", "/api/users/:id", client.Params{"id": id})
+	if err != nil {
+		var zero any
+		return zero, err
+	}
+	return client.Do[any](ctx, c.Client, r)
+}
@@ -0,0 +1,4 @@
+// Package testclient is the client generated for testapi.
+package testclient
+
+//go:generate go run ../gen -o client.gen.go
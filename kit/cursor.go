@@ -0,0 +1,158 @@
+package kit
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+)
+
+// errInvalidCursor hides why a cursor was rejected, so clients cannot probe the signature.
+var errInvalidCursor = errors.New("cursor is invalid")
+
+// Cursor points at the boundary item of a page through its sort key.
+type Cursor struct {
+	Key      json.RawMessage `json:"k"`           // Sort key of the boundary item, as returned by the key function
+	Backward bool            `json:"b,omitempty"` // Whether the page before Key is requested
+}
+
+// DecodeKey decodes the sort key into v, such as an ID or a struct holding a composite key.
+func (c Cursor) DecodeKey(v any) error {
+	return json.Unmarshal(c.Key, v)
+}
+
+// CursorSigner encodes cursors into opaque tokens signed with HMAC-SHA256,
+// so clients cannot forge cursors pointing at arbitrary keys.
+type CursorSigner struct {
+	secret []byte
+}
+
+// NewCursorSigner creates a signer, every instance of a service must share the secret.
+func NewCursorSigner(secret []byte) *CursorSigner {
+	return &CursorSigner{secret: slices.Clone(secret)}
+}
+
+// Encode returns the URL safe base64 token of cursor.
+func (s *CursorSigner) Encode(cursor Cursor) (string, error) {
+	payload, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(append(payload, s.sign(payload)...)), nil
+}
+
+// Decode verifies token and returns its cursor.
+// Malformed and tampered tokens are reported as ErrInvalidArgument.
+func (s *CursorSigner) Decode(token string) (Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || len(data) < sha256.Size {
+		return Cursor{}, invalidQuery("cursor", errInvalidCursor)
+	}
+
+	payload, signature := data[:len(data)-sha256.Size], data[len(data)-sha256.Size:]
+	if !hmac.Equal(signature, s.sign(payload)) {
+		return Cursor{}, invalidQuery("cursor", errInvalidCursor)
+	}
+
+	var cursor Cursor
+	if err := json.Unmarshal(payload, &cursor); err != nil || len(cursor.Key) == 0 {
+		return Cursor{}, invalidQuery("cursor", errInvalidCursor)
+	}
+	return cursor, nil
+}
+
+func (s *CursorSigner) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+// CursorRequest is a parsed cursor page request.
+type CursorRequest struct {
+	Cursor *Cursor // Requested position, nil for the first page
+	Limit  int     // Number of items of the page, DefaultPageLimits.Default when not positive
+}
+
+// FetchLimit is the number of rows to query, one more than Limit to detect whether more items follow.
+func (r CursorRequest) FetchLimit() int {
+	return r.limit() + 1
+}
+
+func (r CursorRequest) limit() int {
+	if r.Limit <= 0 {
+		return DefaultPageLimits.Default
+	}
+	return r.Limit
+}
+
+// ParseCursorRequest reads the cursor and limit query parameters, the limit is clamped like in ParseOffsetPage.
+//
+// Queries should select FetchLimit items after the cursor key in sort order, or before it in
+// reverse sort order when the cursor is Backward, and pass them as is to NewCursorPage.
+func (s *CursorSigner) ParseCursorRequest(ctx *gin.Context, limits PageLimits) (CursorRequest, error) {
+	limit, err := parseLimit(ctx, limits)
+	if err != nil {
+		return CursorRequest{}, err
+	}
+
+	req := CursorRequest{Limit: limit}
+	if token := ctx.Query("cursor"); token != "" {
+		cursor, err := s.Decode(token)
+		if err != nil {
+			return CursorRequest{}, err
+		}
+		req.Cursor = &cursor
+	}
+	return req, nil
+}
+
+// NewCursorPage builds a CursorPage from the items queried for req, key returning the sort key of an item.
+// The extra item fetched through FetchLimit is dropped, and backward pages are put back in sort order.
+func NewCursorPage[T any](signer *CursorSigner, req CursorRequest, items []T, key func(item T) any) (CursorPage, error) {
+	backward := req.Cursor != nil && req.Cursor.Backward
+	limit := req.limit()
+	more := len(items) > limit
+	items = slices.Clone(items[:min(len(items), limit)])
+	if backward {
+		slices.Reverse(items)
+	}
+
+	page := CursorPage{Items: items}
+	if len(items) == 0 {
+		page.Items = []T{}
+		return page, nil
+	}
+
+	// Going forward, the previous page exists once a cursor was followed.
+	// Going backward, the next page is the one the client came from.
+	hasNext, hasPrev := more, req.Cursor != nil
+	if backward {
+		hasNext, hasPrev = true, more
+	}
+
+	var err error
+	if hasNext {
+		if page.NextCursor, err = encodeCursor(signer, key(items[len(items)-1]), false); err != nil {
+			return CursorPage{}, err
+		}
+	}
+	if hasPrev {
+		if page.PrevCursor, err = encodeCursor(signer, key(items[0]), true); err != nil {
+			return CursorPage{}, err
+		}
+	}
+	page.HasMore = hasNext
+	return page, nil
+}
+
+func encodeCursor(signer *CursorSigner, key any, backward bool) (string, error) {
+	data, err := json.Marshal(key)
+	if err != nil {
+		return "", err
+	}
+	return signer.Encode(Cursor{Key: data, Backward: backward})
+}
@@ -0,0 +1,152 @@
+package kit
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/url"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type cursorRow struct {
+	ID int `json:"id"`
+}
+
+// queryRows emulates WHERE id > ? ORDER BY id LIMIT ? and its backward counterpart.
+func queryRows(t *testing.T, rows []cursorRow, req CursorRequest) []cursorRow {
+	t.Helper()
+	var result []cursorRow
+	switch {
+	case req.Cursor == nil:
+		result = rows
+	case req.Cursor.Backward:
+		var id int
+		assert.NoError(t, req.Cursor.DecodeKey(&id))
+		for _, row := range slices.Backward(rows) {
+			if row.ID < id {
+				result = append(result, row)
+			}
+		}
+	default:
+		var id int
+		assert.NoError(t, req.Cursor.DecodeKey(&id))
+		for _, row := range rows {
+			if row.ID > id {
+				result = append(result, row)
+			}
+		}
+	}
+	return result[:min(len(result), req.FetchLimit())]
+}
+
+func TestCursorSigner(t *testing.T) {
+	signer := NewCursorSigner([]byte("secret"))
+
+	token, err := signer.Encode(Cursor{Key: json.RawMessage(`{"id":7}`), Backward: true})
+	assert.NoError(t, err)
+
+	cursor, err := signer.Decode(token)
+	assert.NoError(t, err)
+	assert.True(t, cursor.Backward)
+	var key cursorRow
+	assert.NoError(t, cursor.DecodeKey(&key))
+	assert.Equal(t, cursorRow{ID: 7}, key)
+
+	t.Run("tampered", func(t *testing.T) {
+		data, _ := base64.RawURLEncoding.DecodeString(token)
+		data[len(`{"k":{"id":`)] = '8'
+		_, err := signer.Decode(base64.RawURLEncoding.EncodeToString(data))
+		assert.ErrorIs(t, err, NewInvalidArgumentError())
+	})
+
+	t.Run("other secret", func(t *testing.T) {
+		_, err := NewCursorSigner([]byte("other")).Decode(token)
+		assert.ErrorIs(t, err, NewInvalidArgumentError())
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		for _, token := range []string{"", "!!!", "c2hvcnQ"} {
+			_, err := signer.Decode(token)
+			assert.ErrorIs(t, err, NewInvalidArgumentError(), token)
+		}
+	})
+}
+
+func TestCursorSigner_ParseCursorRequest(t *testing.T) {
+	signer := NewCursorSigner([]byte("secret"))
+
+	req, err := signer.ParseCursorRequest(newQueryContext("limit=500"), PageLimits{Default: 10, Max: 50})
+	assert.NoError(t, err)
+	assert.Equal(t, CursorRequest{Limit: 50}, req)
+	assert.Equal(t, 51, req.FetchLimit())
+
+	token, _ := signer.Encode(Cursor{Key: json.RawMessage("3")})
+	req, err = signer.ParseCursorRequest(newQueryContext("cursor="+url.QueryEscape(token)), PageLimits{})
+	assert.NoError(t, err)
+	assert.Equal(t, CursorRequest{Cursor: &Cursor{Key: json.RawMessage("3")}, Limit: 20}, req)
+
+	_, err = signer.ParseCursorRequest(newQueryContext("cursor=forged"), PageLimits{})
+	assert.ErrorIs(t, err, NewInvalidArgumentError())
+	assert.Equal(t, []Detail{BadRequest{FieldViolations: []FieldViolation{{Field: "cursor", Description: "cursor is invalid"}}}},
+		err.(*Exception).Details())
+
+	_, err = signer.ParseCursorRequest(newQueryContext("limit=x"), PageLimits{})
+	assert.ErrorIs(t, err, NewInvalidArgumentError())
+}
+
+func TestNewCursorPage(t *testing.T) {
+	signer := NewCursorSigner([]byte("secret"))
+	rows := []cursorRow{{1}, {2}, {3}, {4}, {5}, {6}, {7}}
+	key := func(row cursorRow) any { return row.ID }
+
+	fetch := func(token string) CursorPage {
+		t.Helper()
+		req, err := signer.ParseCursorRequest(newQueryContext("limit=3&cursor="+url.QueryEscape(token)), PageLimits{})
+		assert.NoError(t, err)
+		page, err := NewCursorPage(signer, req, queryRows(t, rows, req), key)
+		assert.NoError(t, err)
+		return page
+	}
+
+	first := fetch("")
+	assert.Equal(t, []cursorRow{{1}, {2}, {3}}, first.Items)
+	assert.True(t, first.HasMore)
+	assert.Empty(t, first.PrevCursor)
+
+	second := fetch(first.NextCursor)
+	assert.Equal(t, []cursorRow{{4}, {5}, {6}}, second.Items)
+	assert.True(t, second.HasMore)
+	assert.NotEmpty(t, second.PrevCursor)
+
+	last := fetch(second.NextCursor)
+	assert.Equal(t, []cursorRow{{7}}, last.Items)
+	assert.False(t, last.HasMore)
+	assert.Empty(t, last.NextCursor)
+
+	back := fetch(last.PrevCursor)
+	assert.Equal(t, []cursorRow{{4}, {5}, {6}}, back.Items)
+	assert.True(t, back.HasMore)
+
+	front := fetch(back.PrevCursor)
+	assert.Equal(t, []cursorRow{{1}, {2}, {3}}, front.Items)
+	assert.Empty(t, front.PrevCursor)
+	assert.Equal(t, first.NextCursor, front.NextCursor)
+
+	for _, limit := range []int{0, -1} {
+		req := CursorRequest{Limit: limit}
+		assert.Equal(t, DefaultPageLimits.Default+1, req.FetchLimit())
+		page, err := NewCursorPage(signer, req, rows, key)
+		assert.NoError(t, err)
+		assert.Equal(t, rows, page.Items, "a non-positive limit is the default limit")
+		assert.False(t, page.HasMore)
+	}
+
+	empty, err := NewCursorPage(signer, CursorRequest{Limit: 3}, []cursorRow(nil), key)
+	assert.NoError(t, err)
+	assert.Equal(t, CursorPage{Items: []cursorRow{}}, empty)
+
+	data, _ := json.Marshal(empty)
+	assert.JSONEq(t, `{"items":[],"has_more":false}`, string(data))
+}
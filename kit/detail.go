@@ -0,0 +1,200 @@
+package kit
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Type URLs of the error details, matching the google.rpc error detail messages.
+const (
+	TypeURLBadRequest   = "type.googleapis.com/google.rpc.BadRequest"
+	TypeURLQuotaFailure = "type.googleapis.com/google.rpc.QuotaFailure"
+	TypeURLRetryInfo    = "type.googleapis.com/google.rpc.RetryInfo"
+	TypeURLErrorInfo    = "type.googleapis.com/google.rpc.ErrorInfo"
+)
+
+// Detail is a structured error detail carried by an Exception.
+// Reference: [Google's API Design Guide](https://cloud.google.com/apis/design/errors#error_details)
+type Detail interface {
+	TypeURL() string // Identifies the detail kind, serialized as "@type"
+}
+
+// DetailedError is implemented by business errors that carry structured details.
+type DetailedError interface {
+	Details() []Detail
+}
+
+// FieldViolation describes a single bad request field.
+type FieldViolation struct {
+	Field       string `json:"field"`       // Path to the bad field, such as "user.email"
+	Description string `json:"description"` // Why the field is bad
+}
+
+// BadRequest describes violations in a client request.
+type BadRequest struct {
+	FieldViolations []FieldViolation `json:"field_violations"`
+}
+
+// QuotaViolation describes a single quota violation.
+type QuotaViolation struct {
+	Subject     string `json:"subject"`     // The subject on which the quota check failed, such as "user:123"
+	Description string `json:"description"` // How the quota check failed
+}
+
+// QuotaFailure describes how a quota check failed.
+type QuotaFailure struct {
+	Violations []QuotaViolation `json:"violations"`
+}
+
+// RetryInfo tells the client when it may retry a failed request.
+type RetryInfo struct {
+	RetryDelay time.Duration `json:"-"` // Serialized as "retry_delay", such as "1.5s"
+}
+
+// ErrorInfo describes the cause of the error with structured machine-readable data.
+type ErrorInfo struct {
+	Reason   string            `json:"reason"`             // UPPER_SNAKE_CASE reason of the error, such as "COUPON_EXPIRED"
+	Domain   string            `json:"domain,omitempty"`   // Logical grouping the reason belongs to
+	Metadata map[string]string `json:"metadata,omitempty"` // Additional structured details
+}
+
+// RawDetail holds a detail whose type is not known to this package.
+type RawDetail struct {
+	Type  string          // The "@type" of the detail
+	Value json.RawMessage // The complete JSON object, including "@type"
+}
+
+func (d BadRequest) TypeURL() string   { return TypeURLBadRequest }
+func (d QuotaFailure) TypeURL() string { return TypeURLQuotaFailure }
+func (d RetryInfo) TypeURL() string    { return TypeURLRetryInfo }
+func (d ErrorInfo) TypeURL() string    { return TypeURLErrorInfo }
+func (d RawDetail) TypeURL() string    { return d.Type }
+
+// MarshalJSON implements json.Marshaler, adding the "@type" field.
+func (d BadRequest) MarshalJSON() ([]byte, error) {
+	type alias BadRequest
+	return marshalDetail(d, alias(d))
+}
+
+// MarshalJSON implements json.Marshaler, adding the "@type" field.
+func (d QuotaFailure) MarshalJSON() ([]byte, error) {
+	type alias QuotaFailure
+	return marshalDetail(d, alias(d))
+}
+
+// MarshalJSON implements json.Marshaler, adding the "@type" field.
+func (d ErrorInfo) MarshalJSON() ([]byte, error) {
+	type alias ErrorInfo
+	return marshalDetail(d, alias(d))
+}
+
+// MarshalJSON implements json.Marshaler, adding the "@type" field
+// and encoding the delay as a duration string.
+func (d RetryInfo) MarshalJSON() ([]byte, error) {
+	return marshalDetail(d, struct {
+		RetryDelay string `json:"retry_delay"`
+	}{d.RetryDelay.String()})
+}
+
+// UnmarshalJSON implements json.Unmarshaler, parsing the duration string.
+func (d *RetryInfo) UnmarshalJSON(data []byte) error {
+	var v struct {
+		RetryDelay string `json:"retry_delay"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	delay, err := time.ParseDuration(v.RetryDelay)
+	if err != nil {
+		return fmt.Errorf("failed to parse retry delay: %w", err)
+	}
+	d.RetryDelay = delay
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, returning the raw value.
+func (d RawDetail) MarshalJSON() ([]byte, error) {
+	return d.Value, nil
+}
+
+func marshalDetail(d Detail, fields any) ([]byte, error) {
+	body, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+	typeURL, err := json.Marshal(d.TypeURL())
+	if err != nil {
+		return nil, err
+	}
+
+	out := append([]byte(`{"@type":`), typeURL...)
+	if len(body) > len("{}") {
+		out = append(out, ',')
+	}
+	return append(out, body[1:]...), nil
+}
+
+// Details is a list of error details that can be decoded back into the
+// concrete detail types based on their "@type".
+type Details []Detail
+
+// UnmarshalJSON implements json.Unmarshaler.
+// Details with an unknown "@type" are decoded as RawDetail.
+func (d *Details) UnmarshalJSON(data []byte) error {
+	var raws []json.RawMessage
+	if err := json.Unmarshal(data, &raws); err != nil {
+		return err
+	}
+
+	details := make(Details, 0, len(raws))
+	for _, raw := range raws {
+		detail, err := unmarshalDetail(raw)
+		if err != nil {
+			return err
+		}
+		details = append(details, detail)
+	}
+	*d = details
+	return nil
+}
+
+func unmarshalDetail(raw json.RawMessage) (Detail, error) {
+	var head struct {
+		Type string `json:"@type"`
+	}
+	if err := json.Unmarshal(raw, &head); err != nil {
+		return nil, err
+	}
+
+	var detail Detail
+	var err error
+	switch head.Type {
+	case TypeURLBadRequest:
+		v := BadRequest{}
+		err = json.Unmarshal(raw, &v)
+		detail = v
+	case TypeURLQuotaFailure:
+		v := QuotaFailure{}
+		err = json.Unmarshal(raw, &v)
+		detail = v
+	case TypeURLRetryInfo:
+		v := RetryInfo{}
+		err = json.Unmarshal(raw, &v)
+		detail = v
+	case TypeURLErrorInfo:
+		v := ErrorInfo{}
+		err = json.Unmarshal(raw, &v)
+		detail = v
+	default:
+		detail = RawDetail{Type: head.Type, Value: raw}
+	}
+	return detail, err
+}
+
+var _ Detail = BadRequest{}
+var _ Detail = QuotaFailure{}
+var _ Detail = RetryInfo{}
+var _ Detail = ErrorInfo{}
+var _ Detail = RawDetail{}
+var _ json.Unmarshaler = (*Details)(nil)
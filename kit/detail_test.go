@@ -0,0 +1,94 @@
+package kit
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetail_MarshalJSON(t *testing.T) {
+	testCases := []struct {
+		name     string
+		detail   Detail
+		expected string
+	}{
+		{
+			"BadRequest",
+			BadRequest{FieldViolations: []FieldViolation{{Field: "email", Description: "invalid format"}}},
+			`{"@type":"type.googleapis.com/google.rpc.BadRequest","field_violations":[{"field":"email","description":"invalid format"}]}`,
+		},
+		{
+			"QuotaFailure",
+			QuotaFailure{Violations: []QuotaViolation{{Subject: "user:1", Description: "daily limit"}}},
+			`{"@type":"type.googleapis.com/google.rpc.QuotaFailure","violations":[{"subject":"user:1","description":"daily limit"}]}`,
+		},
+		{
+			"RetryInfo",
+			RetryInfo{RetryDelay: 1500 * time.Millisecond},
+			`{"@type":"type.googleapis.com/google.rpc.RetryInfo","retry_delay":"1.5s"}`,
+		},
+		{
+			"ErrorInfo",
+			ErrorInfo{Reason: "COUPON_EXPIRED", Domain: "shop", Metadata: map[string]string{"coupon": "X1"}},
+			`{"@type":"type.googleapis.com/google.rpc.ErrorInfo","reason":"COUPON_EXPIRED","domain":"shop","metadata":{"coupon":"X1"}}`,
+		},
+		{
+			"ErrorInfo without optional fields",
+			ErrorInfo{Reason: "COUPON_EXPIRED"},
+			`{"@type":"type.googleapis.com/google.rpc.ErrorInfo","reason":"COUPON_EXPIRED"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			data, err := json.Marshal(tc.detail)
+			assert.NoError(t, err)
+			assert.JSONEq(t, tc.expected, string(data))
+		})
+	}
+}
+
+func TestDetails_UnmarshalJSON(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		details := Details{
+			BadRequest{FieldViolations: []FieldViolation{{Field: "name", Description: "required"}}},
+			QuotaFailure{Violations: []QuotaViolation{{Subject: "user:1", Description: "daily limit"}}},
+			RetryInfo{RetryDelay: 2 * time.Second},
+			ErrorInfo{Reason: "COUPON_EXPIRED", Metadata: map[string]string{"coupon": "X1"}},
+		}
+
+		data, err := json.Marshal(details)
+		assert.NoError(t, err)
+
+		decoded := Details{}
+		assert.NoError(t, json.Unmarshal(data, &decoded))
+		assert.Equal(t, details, decoded)
+	})
+
+	t.Run("unknown type is kept raw", func(t *testing.T) {
+		data := `[{"@type":"type.googleapis.com/google.rpc.Help","links":[]}]`
+
+		decoded := Details{}
+		assert.NoError(t, json.Unmarshal([]byte(data), &decoded))
+		assert.Len(t, decoded, 1)
+		assert.Equal(t, "type.googleapis.com/google.rpc.Help", decoded[0].TypeURL())
+
+		encoded, err := json.Marshal(decoded)
+		assert.NoError(t, err)
+		assert.JSONEq(t, data, string(encoded))
+	})
+
+	t.Run("invalid retry delay", func(t *testing.T) {
+		data := `[{"@type":"type.googleapis.com/google.rpc.RetryInfo","retry_delay":"soon"}]`
+
+		decoded := Details{}
+		assert.Error(t, json.Unmarshal([]byte(data), &decoded))
+	})
+
+	t.Run("not an array", func(t *testing.T) {
+		decoded := Details{}
+		assert.Error(t, json.Unmarshal([]byte(`{}`), &decoded))
+	})
+}
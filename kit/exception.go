@@ -1,12 +1,19 @@
 package kit
 
+import "slices"
+
 type Exception struct {
 	code int    // business code
 	info string // business information, to user
 	desc string // business description, to developer
+
+	details []Detail // structured error details, to user
+	err     error    // underlying cause, exposed through Unwrap
+	frozen  bool     // sentinel exception, decorated through copies only
 }
 
 var _ BusinessError = &Exception{}
+var _ DetailedError = &Exception{}
 var _ error = &Exception{}
 
 func (e *Exception) Code() int {
@@ -21,6 +28,10 @@ func (e *Exception) Desc() string {
 	return e.desc
 }
 
+func (e *Exception) Details() []Detail {
+	return e.details
+}
+
 func (e *Exception) Error() string {
 	if e.desc != "" {
 		return e.desc
@@ -31,24 +42,93 @@ func (e *Exception) Error() string {
 	return Messages[ErrUnknown]
 }
 
-// WithErr set desc = err.Error() when error is not nil
+// Unwrap returns the cause set by WithErr, so errors.Is and errors.As can inspect it
+func (e *Exception) Unwrap() error {
+	return e.err
+}
+
+// Is reports whether target is a BusinessError with the same code,
+// so errors.Is(err, NewNotFoundError()) matches any ErrNotFound exception
+func (e *Exception) Is(target error) bool {
+	t, ok := target.(BusinessError)
+	if !ok || t == nil {
+		return false
+	}
+	if ex, ok := t.(*Exception); ok && ex == nil {
+		return false
+	}
+	return t.Code() == e.code
+}
+
+// Freeze returns a frozen copy of the exception, meant to be stored as a package sentinel.
+// With* calls on a frozen exception return a decorated copy and leave the sentinel untouched,
+// so it is safe to share between goroutines.
+//
+//	var ErrUserMissing = kit.NewNotFoundError().WithInfo("user missing").Freeze()
+func (e *Exception) Freeze() *Exception {
+	c := e.Clone()
+	c.frozen = true
+	return c
+}
+
+// Frozen reports whether the exception is a frozen sentinel
+func (e *Exception) Frozen() bool {
+	return e.frozen
+}
+
+// Clone returns a mutable copy of the exception
+func (e *Exception) Clone() *Exception {
+	c := *e
+	c.frozen = false
+	c.details = slices.Clone(e.details)
+	return &c
+}
+
+// mutable returns e itself, or a copy when e is frozen
+func (e *Exception) mutable() *Exception {
+	if e.frozen {
+		return e.Clone()
+	}
+	return e
+}
+
+// WithErr set desc = err.Error() and keep err as the cause when error is not nil
 func (e *Exception) WithErr(err error) *Exception {
-	if err != nil {
-		e.desc = err.Error()
+	if err == nil {
+		return e
 	}
+	e = e.mutable()
+	e.desc = err.Error()
+	e.err = err
+	return e
+}
+
+// WithDesc set desc without a cause, such as a description received from a remote service
+func (e *Exception) WithDesc(desc string) *Exception {
+	e = e.mutable()
+	e.desc = desc
 	return e
 }
 
 func (e *Exception) WithCode(code int) *Exception {
+	e = e.mutable()
 	e.code = code
 	return e
 }
 
 func (e *Exception) WithInfo(info string) *Exception {
+	e = e.mutable()
 	e.info = info
 	return e
 }
 
+// WithDetails appends structured error details, such as BadRequest or ErrorInfo
+func (e *Exception) WithDetails(details ...Detail) *Exception {
+	e = e.mutable()
+	e.details = append(e.details, details...)
+	return e
+}
+
 func newException(code int, info string) *Exception {
 	return (&Exception{}).WithCode(code).WithInfo(info)
 }
@@ -2,6 +2,8 @@ package kit
 
 import (
 	"errors"
+	"fmt"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -36,6 +38,19 @@ func TestException_BasicFunctionality(t *testing.T) {
 		ex := NewException().WithErr(nil)
 		assert.Equal(t, "", ex.Desc())
 	})
+
+	t.Run("WithDesc", func(t *testing.T) {
+		ex := NewException().WithDesc("remote failure")
+		assert.Equal(t, "remote failure", ex.Desc())
+		assert.Nil(t, ex.Unwrap())
+	})
+
+	t.Run("WithDetails", func(t *testing.T) {
+		badRequest := BadRequest{FieldViolations: []FieldViolation{{Field: "name", Description: "required"}}}
+		errorInfo := ErrorInfo{Reason: "NAME_MISSING"}
+		ex := NewInvalidArgumentError().WithDetails(badRequest).WithDetails(errorInfo)
+		assert.Equal(t, []Detail{badRequest, errorInfo}, ex.Details())
+	})
 }
 
 func TestException_ErrorMethod(t *testing.T) {
@@ -159,3 +174,112 @@ func TestException_ErrorMessages(t *testing.T) {
 		}
 	})
 }
+
+func TestException_Wrapping(t *testing.T) {
+	t.Run("Unwrap returns cause", func(t *testing.T) {
+		cause := errors.New("connection refused")
+		ex := NewUnavailableError().WithErr(cause)
+		assert.Equal(t, cause, ex.Unwrap())
+		assert.True(t, errors.Is(ex, cause))
+	})
+
+	t.Run("Unwrap without cause", func(t *testing.T) {
+		assert.Nil(t, NewNotFoundError().Unwrap())
+	})
+
+	t.Run("Is matches on code", func(t *testing.T) {
+		ex := NewNotFoundError().WithInfo("user missing").WithErr(errors.New("no rows"))
+		assert.True(t, errors.Is(ex, NewNotFoundError()))
+		assert.False(t, errors.Is(ex, NewAlreadyExistsError()))
+	})
+
+	t.Run("Is through fmt.Errorf wrapping", func(t *testing.T) {
+		err := fmt.Errorf("load user: %w", NewNotFoundError())
+		assert.True(t, errors.Is(err, NewNotFoundError()))
+	})
+
+	t.Run("Is with non business target", func(t *testing.T) {
+		assert.False(t, errors.Is(NewNotFoundError(), errors.New("other")))
+		assert.False(t, NewNotFoundError().Is((*Exception)(nil)))
+	})
+
+	t.Run("As finds wrapped exception", func(t *testing.T) {
+		err := fmt.Errorf("load user: %w", NewNotFoundError().WithInfo("user missing"))
+
+		var ex *Exception
+		assert.True(t, errors.As(err, &ex))
+		assert.Equal(t, ErrNotFound, ex.Code())
+		assert.Equal(t, "user missing", ex.Info())
+	})
+}
+
+func TestException_Freeze(t *testing.T) {
+	t.Run("decorating a frozen exception returns a copy", func(t *testing.T) {
+		sentinel := NewNotFoundError().WithInfo("user missing").Freeze()
+
+		cause := errors.New("no rows")
+		ex := sentinel.WithErr(cause).WithDetails(ErrorInfo{Reason: "USER_MISSING"})
+
+		assert.NotSame(t, sentinel, ex)
+		assert.False(t, ex.Frozen())
+		assert.Equal(t, ErrNotFound, ex.Code())
+		assert.Equal(t, "user missing", ex.Info())
+		assert.Equal(t, cause.Error(), ex.Desc())
+		assert.Len(t, ex.Details(), 1)
+
+		assert.True(t, sentinel.Frozen())
+		assert.Equal(t, "", sentinel.Desc())
+		assert.Nil(t, sentinel.Unwrap())
+		assert.Empty(t, sentinel.Details())
+		assert.True(t, errors.Is(ex, sentinel))
+	})
+
+	t.Run("every builder copies", func(t *testing.T) {
+		sentinel := NewNotFoundError().Freeze()
+
+		assert.NotSame(t, sentinel, sentinel.WithCode(ErrAborted))
+		assert.NotSame(t, sentinel, sentinel.WithInfo("info"))
+		assert.NotSame(t, sentinel, sentinel.WithDesc("desc"))
+		assert.NotSame(t, sentinel, sentinel.WithDetails(ErrorInfo{}))
+		assert.Same(t, sentinel, sentinel.WithErr(nil))
+		assert.Equal(t, ErrNotFound, sentinel.Code())
+		assert.Equal(t, Messages[ErrNotFound], sentinel.Info())
+	})
+
+	t.Run("Freeze does not modify the receiver", func(t *testing.T) {
+		ex := NewNotFoundError()
+		frozen := ex.Freeze()
+		assert.False(t, ex.Frozen())
+		assert.True(t, frozen.Frozen())
+		assert.Same(t, ex, ex.WithInfo("still mutable"))
+	})
+
+	t.Run("Clone does not share details", func(t *testing.T) {
+		ex := NewInvalidArgumentError().WithDetails(ErrorInfo{Reason: "A"})
+		clone := ex.Clone().WithDetails(ErrorInfo{Reason: "B"})
+		assert.Len(t, ex.Details(), 1)
+		assert.Len(t, clone.Details(), 2)
+	})
+
+	t.Run("concurrent decoration of a sentinel", func(t *testing.T) {
+		sentinel := NewNotFoundError().WithDetails(ErrorInfo{Reason: "USER_MISSING"}).Freeze()
+
+		var wg sync.WaitGroup
+		for i := 0; i < 50; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				ex := sentinel.WithErr(fmt.Errorf("attempt %d", i)).
+					WithInfo(fmt.Sprintf("info %d", i)).
+					WithDetails(ErrorInfo{Reason: "ATTEMPT"})
+				assert.Equal(t, fmt.Sprintf("attempt %d", i), ex.Desc())
+				assert.Len(t, ex.Details(), 2)
+			}(i)
+		}
+		wg.Wait()
+
+		assert.Equal(t, "", sentinel.Desc())
+		assert.Equal(t, Messages[ErrNotFound], sentinel.Info())
+		assert.Len(t, sentinel.Details(), 1)
+	})
+}
@@ -5,8 +5,8 @@ package kit
 
 import (
 	"github.com/gin-gonic/gin"
-	"go.uber.org/zap"
 	"net/http"
+	"slices"
 )
 
 const (
@@ -17,49 +17,146 @@ const (
 // This allows for standardized error handling through the TranslateFunc middleware.
 type HandlerFunc func(ctx *gin.Context) (any, error)
 
+// Middleware wraps a HandlerFunc, it can short-circuit the route by returning
+// an error, such as a BusinessError, without calling next.
+// Errors are rendered through the same RespBody pipeline as handler errors.
+type Middleware func(next HandlerFunc) HandlerFunc
+
+// WrapHandler applies the middlewares to handler, the first middleware being the outermost.
+func WrapHandler(handler HandlerFunc, middlewares ...Middleware) HandlerFunc {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		handler = middlewares[i](handler)
+	}
+	return handler
+}
+
 // RouterGroup wraps gin.RouterGroup and provides methods that accept HandlerFunc
 // instead of gin.HandlerFunc, enabling automatic error handling.
 type RouterGroup struct {
-	gin *gin.RouterGroup
+	gin         *gin.RouterGroup
+	translator  *Translator
+	routes      *routeTable  // shared with nested groups
+	doc         *RouteDoc    // documentation of the next registered route
+	middlewares []Middleware // wrap every route of the group and of its nested groups
 }
 
 // NewRouterGroup creates a new RouterGroup wrapper around the given gin.RouterGroup.
+// Handlers are translated by DefaultTranslator.
 func NewRouterGroup(group *gin.RouterGroup) *RouterGroup {
+	return NewRouterGroupWithTranslator(group, DefaultTranslator)
+}
+
+// NewRouterGroupWithTranslator creates a new RouterGroup wrapper whose handlers
+// are translated by the given Translator.
+func NewRouterGroupWithTranslator(group *gin.RouterGroup, translator *Translator) *RouterGroup {
 	return &RouterGroup{
-		gin: group,
+		gin:        group,
+		translator: translator,
+		routes:     &routeTable{},
 	}
 }
 
-// GET registers a GET route with the given path and handler.
-func (r *RouterGroup) GET(relativePath string, handler HandlerFunc) *RouterGroup {
-	r.gin.GET(relativePath, TranslateFunc(handler))
-	return r
+// anyMethods are the methods registered by Any, matching gin.RouterGroup.Any.
+var anyMethods = []string{
+	http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch,
+	http.MethodHead, http.MethodOptions, http.MethodDelete, http.MethodConnect,
+	http.MethodTrace,
 }
 
-// POST registers a POST route with the given path and handler.
-func (r *RouterGroup) POST(relativePath string, handler HandlerFunc) *RouterGroup {
-	r.gin.POST(relativePath, TranslateFunc(handler))
-	return r
+// Group creates a nested RouterGroup with the given path prefix and gin middlewares.
+// The nested group shares the Translator and the recorded routes of its parent,
+// and inherits the Middlewares added to its parent by UseMiddleware so far.
+func (r *RouterGroup) Group(relativePath string, middlewares ...gin.HandlerFunc) *RouterGroup {
+	return &RouterGroup{
+		gin:         r.gin.Group(relativePath, middlewares...),
+		translator:  r.translator,
+		routes:      r.routes,
+		middlewares: slices.Clip(r.middlewares),
+	}
 }
 
-// DELETE registers a DELETE route with the given path and handler.
-func (r *RouterGroup) DELETE(relativePath string, handler HandlerFunc) *RouterGroup {
-	r.gin.DELETE(relativePath, TranslateFunc(handler))
+// Use adds gin middlewares to the group.
+func (r *RouterGroup) Use(middlewares ...gin.HandlerFunc) *RouterGroup {
+	r.gin.Use(middlewares...)
 	return r
 }
 
-// PATCH registers a PATCH route with the given path and handler.
-func (r *RouterGroup) PATCH(relativePath string, handler HandlerFunc) *RouterGroup {
-	r.gin.PATCH(relativePath, TranslateFunc(handler))
+// UseMiddleware adds Middlewares to the group. They wrap the routes registered afterwards,
+// outside of their route middlewares, and are inherited by the groups nested afterwards.
+func (r *RouterGroup) UseMiddleware(middlewares ...Middleware) *RouterGroup {
+	r.middlewares = append(slices.Clip(r.middlewares), middlewares...)
 	return r
 }
 
-// PUT registers a PUT route with the given path and handler.
-func (r *RouterGroup) PUT(relativePath string, handler HandlerFunc) *RouterGroup {
-	r.gin.PUT(relativePath, TranslateFunc(handler))
+// BasePath returns the base path of the group.
+func (r *RouterGroup) BasePath() string {
+	return r.gin.BasePath()
+}
+
+// Handle registers a route with the given method, path, handler and route middlewares.
+func (r *RouterGroup) Handle(httpMethod, relativePath string, handler HandlerFunc, middlewares ...Middleware) *RouterGroup {
+	return r.Match([]string{httpMethod}, relativePath, handler, middlewares...)
+}
+
+// Match registers a route for each of the given methods with the given path, handler and route middlewares.
+func (r *RouterGroup) Match(methods []string, relativePath string, handler HandlerFunc, middlewares ...Middleware) *RouterGroup {
+	doc := r.takeDoc()
+	request, response := describeHandler(handler)
+	middlewares = append(slices.Clip(r.middlewares), middlewares...)
+	translated := r.translator.Translate(WrapHandler(handler, middlewares...))
+	for _, method := range methods {
+		r.gin.Handle(method, relativePath, translated)
+		r.routes.add(RouteInfo{
+			Method:   method,
+			Path:     joinPaths(r.gin.BasePath(), relativePath),
+			Request:  request,
+			Response: response,
+			Doc:      doc,
+		})
+	}
 	return r
 }
 
+// Any registers a route matching all HTTP methods with the given path, handler and route middlewares.
+func (r *RouterGroup) Any(relativePath string, handler HandlerFunc, middlewares ...Middleware) *RouterGroup {
+	return r.Match(anyMethods, relativePath, handler, middlewares...)
+}
+
+// GET registers a GET route with the given path, handler and route middlewares.
+func (r *RouterGroup) GET(relativePath string, handler HandlerFunc, middlewares ...Middleware) *RouterGroup {
+	return r.Handle(http.MethodGet, relativePath, handler, middlewares...)
+}
+
+// POST registers a POST route with the given path, handler and route middlewares.
+func (r *RouterGroup) POST(relativePath string, handler HandlerFunc, middlewares ...Middleware) *RouterGroup {
+	return r.Handle(http.MethodPost, relativePath, handler, middlewares...)
+}
+
+// DELETE registers a DELETE route with the given path, handler and route middlewares.
+func (r *RouterGroup) DELETE(relativePath string, handler HandlerFunc, middlewares ...Middleware) *RouterGroup {
+	return r.Handle(http.MethodDelete, relativePath, handler, middlewares...)
+}
+
+// PATCH registers a PATCH route with the given path, handler and route middlewares.
+func (r *RouterGroup) PATCH(relativePath string, handler HandlerFunc, middlewares ...Middleware) *RouterGroup {
+	return r.Handle(http.MethodPatch, relativePath, handler, middlewares...)
+}
+
+// PUT registers a PUT route with the given path, handler and route middlewares.
+func (r *RouterGroup) PUT(relativePath string, handler HandlerFunc, middlewares ...Middleware) *RouterGroup {
+	return r.Handle(http.MethodPut, relativePath, handler, middlewares...)
+}
+
+// HEAD registers a HEAD route with the given path, handler and route middlewares.
+func (r *RouterGroup) HEAD(relativePath string, handler HandlerFunc, middlewares ...Middleware) *RouterGroup {
+	return r.Handle(http.MethodHead, relativePath, handler, middlewares...)
+}
+
+// OPTIONS registers an OPTIONS route with the given path, handler and route middlewares.
+func (r *RouterGroup) OPTIONS(relativePath string, handler HandlerFunc, middlewares ...Middleware) *RouterGroup {
+	return r.Handle(http.MethodOptions, relativePath, handler, middlewares...)
+}
+
 // TranslateFunc 将 HandlerFunc 转换为 gin.HandlerFunc
 // 并处理错误，返回统一的响应格式
 // 如果发生错误，返回 RespBody 中的 Succeeded 为 false，并包含错误信息
@@ -67,40 +164,8 @@ func (r *RouterGroup) PUT(relativePath string, handler HandlerFunc) *RouterGroup
 // 如果发生业务错误，返回 RespBody 中的 Code 和 Info 字段
 // 如果发生非业务错误，返回 RespBody 中的 Code 为 InternalErrorCode，并包含错误信息
 // 如果在开发模式下，返回 RespBody 中的 Desc 字段包含详细错误描述
+// HTTP 状态码由 DefaultStatusMapping 根据业务码决定
+// 行为由 DefaultTranslator 提供，需要定制时请使用 NewTranslator
 func TranslateFunc(fun HandlerFunc) gin.HandlerFunc {
-	return func(ctx *gin.Context) {
-		if ctx.IsAborted() {
-			return
-		}
-
-		logger := zap.S().Named("TranslateFunc")
-
-		resp, err := fun(ctx)
-		if err != nil {
-			respBody := RespBody{
-				Succeeded: false,
-			}
-
-			switch ex := err.(type) {
-			case BusinessError:
-				respBody.Code = ex.Code()
-				respBody.Info = ex.Info()
-				if gin.IsDebugging() {
-					respBody.Desc = ex.Desc()
-				}
-			default:
-				respBody.Code = InternalErrorCode
-				respBody.Info = Messages[ErrInternal]
-				if gin.IsDebugging() && err != nil {
-					respBody.Desc = err.Error()
-				}
-			}
-
-			logger.Warnf("failed to handler http, code: %d, info: %s, desc: %s", respBody.Code, respBody.Info, respBody.Desc)
-			ctx.JSON(http.StatusOK, respBody)
-			return
-		}
-
-		ctx.JSON(http.StatusOK, RespBody{Succeeded: true, RespData: resp})
-	}
+	return DefaultTranslator.Translate(fun)
 }
@@ -141,7 +141,7 @@ func TestHandler(t *testing.T) {
 		w := httptest.NewRecorder()
 		req, _ := http.NewRequest(http.MethodGet, "/invalidArgument", http.NoBody)
 		app.ServeHTTP(w, req)
-		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
 
 		body, err := io.ReadAll(w.Body)
 		assert.Equal(t, err, nil)
@@ -158,7 +158,7 @@ func TestHandler(t *testing.T) {
 		w := httptest.NewRecorder()
 		req, _ := http.NewRequest(http.MethodGet, "/notFound", http.NoBody)
 		app.ServeHTTP(w, req)
-		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, http.StatusNotFound, w.Code)
 
 		body, err := io.ReadAll(w.Body)
 		assert.Equal(t, err, nil)
@@ -176,7 +176,7 @@ func TestHandler(t *testing.T) {
 		w := httptest.NewRecorder()
 		req, _ := http.NewRequest(http.MethodGet, "/permissionDenied", http.NoBody)
 		app.ServeHTTP(w, req)
-		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, http.StatusForbidden, w.Code)
 
 		body, err := io.ReadAll(w.Body)
 		assert.Equal(t, err, nil)
@@ -193,7 +193,7 @@ func TestHandler(t *testing.T) {
 		w := httptest.NewRecorder()
 		req, _ := http.NewRequest(http.MethodGet, "/customError", http.NoBody)
 		app.ServeHTTP(w, req)
-		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
 
 		body, err := io.ReadAll(w.Body)
 		assert.Equal(t, err, nil)
@@ -272,23 +272,23 @@ func TestHTTPStatusCodes(t *testing.T) {
 		expectedHTTP int
 		expectedCode int
 	}{
-		{"InvalidArgument", NewInvalidArgumentError, http.StatusOK, ErrInvalidArgument},
-		{"FailedPrecondition", NewFailedPreconditionError, http.StatusOK, ErrFailedPrecondition},
-		{"OutOfRange", NewOutOfRangeError, http.StatusOK, ErrOutOfRange},
-		{"Unauthenticated", NewUnauthenticatedError, http.StatusOK, ErrUnauthenticated},
-		{"PermissionDenied", NewPermissionDeniedError, http.StatusOK, ErrPermissionDenied},
-		{"NotFound", NewNotFoundError, http.StatusOK, ErrNotFound},
-		{"Aborted", NewAbortedError, http.StatusOK, ErrAborted},
-		{"AlreadyExists", NewAlreadyExistsError, http.StatusOK, ErrAlreadyExists},
-		{"ResourceExhausted", NewResourceExhaustedError, http.StatusOK,
+		{"InvalidArgument", NewInvalidArgumentError, http.StatusBadRequest, ErrInvalidArgument},
+		{"FailedPrecondition", NewFailedPreconditionError, http.StatusBadRequest, ErrFailedPrecondition},
+		{"OutOfRange", NewOutOfRangeError, http.StatusBadRequest, ErrOutOfRange},
+		{"Unauthenticated", NewUnauthenticatedError, http.StatusUnauthorized, ErrUnauthenticated},
+		{"PermissionDenied", NewPermissionDeniedError, http.StatusForbidden, ErrPermissionDenied},
+		{"NotFound", NewNotFoundError, http.StatusNotFound, ErrNotFound},
+		{"Aborted", NewAbortedError, http.StatusConflict, ErrAborted},
+		{"AlreadyExists", NewAlreadyExistsError, http.StatusConflict, ErrAlreadyExists},
+		{"ResourceExhausted", NewResourceExhaustedError, http.StatusTooManyRequests,
 			ErrResourceExhausted},
-		{"Canceled", NewCanceledError, http.StatusOK, ErrCanceled},
-		{"DataLoss", NewDataLossError, http.StatusOK, ErrDataLoss},
-		{"Unknown", NewUnknownError, http.StatusOK, ErrUnknown},
-		{"Internal", NewInternalError, http.StatusOK, ErrInternal},
-		{"NotImplemented", NewNotImplementedError, http.StatusOK, ErrNotImplemented},
-		{"Unavailable", NewUnavailableError, http.StatusOK, ErrUnavailable},
-		{"DeadlineExceeded", NewDeadlineExceededError, http.StatusOK,
+		{"Canceled", NewCanceledError, 499, ErrCanceled},
+		{"DataLoss", NewDataLossError, http.StatusInternalServerError, ErrDataLoss},
+		{"Unknown", NewUnknownError, http.StatusInternalServerError, ErrUnknown},
+		{"Internal", NewInternalError, http.StatusInternalServerError, ErrInternal},
+		{"NotImplemented", NewNotImplementedError, http.StatusNotImplemented, ErrNotImplemented},
+		{"Unavailable", NewUnavailableError, http.StatusServiceUnavailable, ErrUnavailable},
+		{"DeadlineExceeded", NewDeadlineExceededError, http.StatusGatewayTimeout,
 			ErrDeadlineExceeded},
 	}
 
@@ -369,3 +369,239 @@ func TestTranslateFunc_ProductionMode(t *testing.T) {
 		assert.Equal(t, respBody.Desc, "") // Should be empty in production
 	})
 }
+
+func TestTranslateFunc_StatusMapping(t *testing.T) {
+	original := DefaultStatusMapping
+	defer func() { DefaultStatusMapping = original }()
+
+	r := gin.New()
+	r.GET("/notFound", TranslateFunc(func(ctx *gin.Context) (any, error) {
+		return nil, NewNotFoundError()
+	}))
+
+	t.Run("legacy always ok", func(t *testing.T) {
+		DefaultStatusMapping = &StatusMapping{AlwaysOK: true}
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/notFound", http.NoBody)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("per-code override", func(t *testing.T) {
+		DefaultStatusMapping = &StatusMapping{Overrides: map[int]int{ErrNotFound: http.StatusGone}}
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/notFound", http.NoBody)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusGone, w.Code)
+	})
+}
+
+func TestRouterGroup_VerbsAndNesting(t *testing.T) {
+	r := gin.New()
+	api := NewRouterGroup(&r.RouterGroup).Group("/api", func(ctx *gin.Context) {
+		ctx.Header("X-Group", "api")
+	})
+	v1 := api.Group("/v1").Use(func(ctx *gin.Context) {
+		ctx.Header("X-Version", "v1")
+	})
+
+	v1.HEAD("/head", func(ctx *gin.Context) (any, error) { return nil, nil })
+	v1.OPTIONS("/options", func(ctx *gin.Context) (any, error) { return "options", nil })
+	v1.Any("/any", func(ctx *gin.Context) (any, error) { return ctx.Request.Method, nil })
+	v1.Handle(http.MethodGet, "/handle", func(ctx *gin.Context) (any, error) { return "handle", nil })
+	v1.Match([]string{http.MethodGet, http.MethodPost}, "/match", func(ctx *gin.Context) (any, error) {
+		return nil, NewNotFoundError()
+	})
+
+	assert.Equal(t, "/api/v1", v1.BasePath())
+
+	serve := func(method, path string) (*httptest.ResponseRecorder, RespBody) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(method, path, http.NoBody)
+		r.ServeHTTP(w, req)
+
+		respBody := RespBody{}
+		_ = json.Unmarshal(w.Body.Bytes(), &respBody)
+		return w, respBody
+	}
+
+	t.Run("nested group middlewares", func(t *testing.T) {
+		w, respBody := serve(http.MethodGet, "/api/v1/handle")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "api", w.Header().Get("X-Group"))
+		assert.Equal(t, "v1", w.Header().Get("X-Version"))
+		assert.Equal(t, "handle", respBody.RespData)
+	})
+
+	t.Run("HEAD", func(t *testing.T) {
+		w, _ := serve(http.MethodHead, "/api/v1/head")
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("OPTIONS", func(t *testing.T) {
+		_, respBody := serve(http.MethodOptions, "/api/v1/options")
+		assert.Equal(t, "options", respBody.RespData)
+	})
+
+	for _, method := range anyMethods {
+		if method == http.MethodHead {
+			continue
+		}
+		t.Run("Any "+method, func(t *testing.T) {
+			_, respBody := serve(method, "/api/v1/any")
+			assert.Equal(t, true, respBody.Succeeded)
+			assert.Equal(t, method, respBody.RespData)
+		})
+	}
+
+	t.Run("Match keeps the envelope", func(t *testing.T) {
+		for _, method := range []string{http.MethodGet, http.MethodPost} {
+			w, respBody := serve(method, "/api/v1/match")
+			assert.Equal(t, http.StatusNotFound, w.Code)
+			assert.Equal(t, ErrNotFound, respBody.Code)
+		}
+
+		w, _ := serve(http.MethodPut, "/api/v1/match")
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("nested group keeps the translator", func(t *testing.T) {
+		translator := NewTranslator(WithStatusMapping(&StatusMapping{AlwaysOK: true}))
+		group := NewRouterGroupWithTranslator(&r.RouterGroup, translator).Group("/legacy")
+		group.GET("/missing", func(ctx *gin.Context) (any, error) { return nil, NewNotFoundError() })
+
+		w, respBody := serve(http.MethodGet, "/legacy/missing")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, ErrNotFound, respBody.Code)
+	})
+}
+
+func TestRouterGroup_RouteMiddlewares(t *testing.T) {
+	var order []string
+	trace := func(name string) Middleware {
+		return func(next HandlerFunc) HandlerFunc {
+			return func(ctx *gin.Context) (any, error) {
+				order = append(order, name)
+				return next(ctx)
+			}
+		}
+	}
+	requireToken := func(next HandlerFunc) HandlerFunc {
+		return func(ctx *gin.Context) (any, error) {
+			if ctx.GetHeader("Authorization") == "" {
+				return nil, NewUnauthenticatedError()
+			}
+			return next(ctx)
+		}
+	}
+	cached := func(next HandlerFunc) HandlerFunc {
+		return func(ctx *gin.Context) (any, error) {
+			return "cached", nil
+		}
+	}
+
+	r := gin.New()
+	group := NewRouterGroup(&r.RouterGroup)
+	group.GET("/private", func(ctx *gin.Context) (any, error) {
+		order = append(order, "handler")
+		return "secret", nil
+	}, trace("first"), requireToken, trace("second"))
+	group.POST("/cached", func(ctx *gin.Context) (any, error) {
+		return "fresh", nil
+	}, cached)
+
+	serve := func(method, path string, header http.Header) (*httptest.ResponseRecorder, RespBody) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(method, path, http.NoBody)
+		req.Header = header
+		r.ServeHTTP(w, req)
+
+		respBody := RespBody{}
+		_ = json.Unmarshal(w.Body.Bytes(), &respBody)
+		return w, respBody
+	}
+
+	t.Run("middlewares run in order", func(t *testing.T) {
+		order = nil
+		_, respBody := serve(http.MethodGet, "/private", http.Header{"Authorization": {"token"}})
+		assert.Equal(t, "secret", respBody.RespData)
+		assert.Equal(t, []string{"first", "second", "handler"}, order)
+	})
+
+	t.Run("middleware error short-circuits", func(t *testing.T) {
+		order = nil
+		w, respBody := serve(http.MethodGet, "/private", http.Header{})
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Equal(t, false, respBody.Succeeded)
+		assert.Equal(t, ErrUnauthenticated, respBody.Code)
+		assert.Equal(t, []string{"first"}, order)
+	})
+
+	t.Run("middleware can replace the response", func(t *testing.T) {
+		_, respBody := serve(http.MethodPost, "/cached", http.Header{})
+		assert.Equal(t, "cached", respBody.RespData)
+	})
+}
+
+func TestRouterGroup_UseMiddleware(t *testing.T) {
+	var order []string
+	trace := func(name string) Middleware {
+		return func(next HandlerFunc) HandlerFunc {
+			return func(ctx *gin.Context) (any, error) {
+				order = append(order, name)
+				return next(ctx)
+			}
+		}
+	}
+	handler := func(ctx *gin.Context) (any, error) {
+		order = append(order, "handler")
+		return "ok", nil
+	}
+
+	r := gin.New()
+	api := NewRouterGroup(&r.RouterGroup)
+	api.GET("/before", handler)
+	api.UseMiddleware(trace("api"))
+	admin := api.Group("/admin").UseMiddleware(trace("admin"))
+	admin.Doc(RouteDoc{Summary: "Documented"}).GET("/users", handler, trace("route"))
+	api.GET("/public", handler)
+	api.UseMiddleware(trace("late"))
+	admin.GET("/late", handler)
+
+	serve := func(path string) []string {
+		order = nil
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, path, http.NoBody)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		return order
+	}
+
+	assert.Equal(t, []string{"handler"}, serve("/before"))
+	assert.Equal(t, []string{"api", "admin", "route", "handler"}, serve("/admin/users"))
+	assert.Equal(t, []string{"api", "handler"}, serve("/public"))
+	// Middlewares added to the parent after nesting do not reach the nested group
+	assert.Equal(t, []string{"api", "admin", "handler"}, serve("/admin/late"))
+}
+
+func TestWrapHandler(t *testing.T) {
+	handler := func(ctx *gin.Context) (any, error) { return "handler", nil }
+	suffix := func(s string) Middleware {
+		return func(next HandlerFunc) HandlerFunc {
+			return func(ctx *gin.Context) (any, error) {
+				resp, err := next(ctx)
+				return resp.(string) + "<" + s, err
+			}
+		}
+	}
+
+	// The first middleware is the outermost, so it sees the response last
+	resp, err := WrapHandler(handler, suffix("first"), suffix("second"))(nil)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "handler<second<first", resp)
+
+	resp, _ = WrapHandler(handler)(nil)
+	assert.Equal(t, "handler", resp)
+}
@@ -0,0 +1,138 @@
+package kitgrpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/qxsugar/pkg/kit"
+)
+
+type testServer struct {
+	err error
+}
+
+var testServiceDesc = grpc.ServiceDesc{
+	ServiceName: "kit.test.Test",
+	HandlerType: (*any)(nil),
+	Methods: []grpc.MethodDesc{{
+		MethodName: "Unary",
+		Handler: func(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+			in := new(emptypb.Empty)
+			if err := dec(in); err != nil {
+				return nil, err
+			}
+			handler := func(ctx context.Context, req any) (any, error) {
+				return in, srv.(*testServer).err
+			}
+			info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/kit.test.Test/Unary"}
+			return interceptor(ctx, in, info, handler)
+		},
+	}},
+	Streams: []grpc.StreamDesc{{
+		StreamName:    "Stream",
+		ServerStreams: true,
+		Handler: func(srv any, stream grpc.ServerStream) error {
+			return srv.(*testServer).err
+		},
+	}},
+}
+
+func dialTestServer(t *testing.T, err error) *grpc.ClientConn {
+	t.Helper()
+
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer(
+		grpc.UnaryInterceptor(UnaryServerInterceptor()),
+		grpc.StreamInterceptor(StreamServerInterceptor()),
+	)
+	server.RegisterService(&testServiceDesc, &testServer{err: err})
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	conn, dialErr := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	assert.NoError(t, dialErr)
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn
+}
+
+func callUnary(conn *grpc.ClientConn) error {
+	return conn.Invoke(context.Background(), "/kit.test.Test/Unary", &emptypb.Empty{}, &emptypb.Empty{})
+}
+
+func callStream(conn *grpc.ClientConn) error {
+	stream, err := conn.NewStream(context.Background(), &testServiceDesc.Streams[0], "/kit.test.Test/Stream")
+	if err != nil {
+		return err
+	}
+	if err := stream.SendMsg(&emptypb.Empty{}); err != nil {
+		return err
+	}
+	if err := stream.CloseSend(); err != nil {
+		return err
+	}
+	return stream.RecvMsg(&emptypb.Empty{})
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		assert.NoError(t, callUnary(dialTestServer(t, nil)))
+	})
+
+	t.Run("business error", func(t *testing.T) {
+		conn := dialTestServer(t, kit.NewNotFoundError().WithInfo("user missing").
+			WithDetails(kit.ErrorInfo{Reason: "USER_MISSING"}))
+
+		err := callUnary(conn)
+		assert.Equal(t, codes.NotFound, status.Code(err))
+
+		ex := FromError(err)
+		assert.Equal(t, kit.ErrNotFound, ex.Code())
+		assert.Equal(t, "user missing", ex.Info())
+		assert.Equal(t, []kit.Detail{kit.ErrorInfo{Reason: "USER_MISSING"}}, ex.Details())
+	})
+
+	t.Run("plain error", func(t *testing.T) {
+		err := callUnary(dialTestServer(t, errors.New("password=secret")))
+		assert.Equal(t, codes.Internal, status.Code(err))
+		assert.Equal(t, kit.Messages[kit.ErrInternal], status.Convert(err).Message())
+	})
+}
+
+func TestStreamServerInterceptor(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		err := callStream(dialTestServer(t, nil))
+		assert.ErrorIs(t, err, io.EOF) // the stream ends without sending a message
+	})
+
+	t.Run("business error", func(t *testing.T) {
+		err := callStream(dialTestServer(t, kit.NewException().WithCode(40010).WithInfo("Coupon expired")))
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		ex := FromError(err)
+		assert.Equal(t, 40010, ex.Code())
+		assert.Equal(t, "Coupon expired", ex.Info())
+	})
+
+	t.Run("business error without code", func(t *testing.T) {
+		err := callStream(dialTestServer(t, kit.NewException().WithInfo("oops")))
+		assert.NotErrorIs(t, err, io.EOF, "the stream does not end as a success")
+		assert.Equal(t, codes.Unknown, status.Code(err))
+		assert.Equal(t, "oops", status.Convert(err).Message())
+	})
+}
@@ -0,0 +1,233 @@
+// Package kitgrpc converts kit business errors to and from gRPC statuses,
+// so services running gin and gRPC side by side share the same error model.
+package kitgrpc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/qxsugar/pkg/kit"
+)
+
+// ErrorInfoDomain is the domain of the ErrorInfo detail carrying the kit business code,
+// which lets FromStatus restore codes that have no gRPC equivalent, such as 40010.
+const ErrorInfoDomain = "github.com/qxsugar/pkg/kit"
+
+const (
+	reasonBusinessCode = "BUSINESS_CODE"
+	metadataCode       = "code"
+)
+
+var kitToGRPC = map[int]codes.Code{
+	kit.OK:                    codes.OK,
+	kit.ErrInvalidArgument:    codes.InvalidArgument,
+	kit.ErrFailedPrecondition: codes.FailedPrecondition,
+	kit.ErrOutOfRange:         codes.OutOfRange,
+	kit.ErrUnauthenticated:    codes.Unauthenticated,
+	kit.ErrPermissionDenied:   codes.PermissionDenied,
+	kit.ErrNotFound:           codes.NotFound,
+	kit.ErrAborted:            codes.Aborted,
+	kit.ErrAlreadyExists:      codes.AlreadyExists,
+	kit.ErrResourceExhausted:  codes.ResourceExhausted,
+	kit.ErrCanceled:           codes.Canceled,
+	kit.ErrDataLoss:           codes.DataLoss,
+	kit.ErrUnknown:            codes.Unknown,
+	kit.ErrInternal:           codes.Internal,
+	kit.ErrNotImplemented:     codes.Unimplemented,
+	kit.ErrUnavailable:        codes.Unavailable,
+	kit.ErrDeadlineExceeded:   codes.DeadlineExceeded,
+}
+
+var grpcToKit = func() map[codes.Code]int {
+	m := make(map[codes.Code]int, len(kitToGRPC))
+	for kitCode, grpcCode := range kitToGRPC {
+		m[grpcCode] = kitCode
+	}
+	return m
+}()
+
+// Code returns the gRPC code of a kit business code.
+// Custom codes are mapped through their HTTP status, so 40010 becomes InvalidArgument.
+func Code(code int) codes.Code {
+	if c, ok := kitToGRPC[code]; ok {
+		return c
+	}
+
+	httpStatus := kit.HTTPStatus(code)
+	switch httpStatus {
+	case http.StatusBadRequest:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusConflict:
+		return codes.Aborted
+	case http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case http.StatusNotImplemented:
+		return codes.Unimplemented
+	case http.StatusServiceUnavailable:
+		return codes.Unavailable
+	case http.StatusGatewayTimeout:
+		return codes.DeadlineExceeded
+	}
+	if httpStatus < http.StatusInternalServerError {
+		return codes.FailedPrecondition
+	}
+	return codes.Internal
+}
+
+// KitCode returns the kit business code of a gRPC code.
+func KitCode(code codes.Code) int {
+	if c, ok := grpcToKit[code]; ok {
+		return c
+	}
+	return kit.ErrUnknown
+}
+
+// ToStatus converts err into a gRPC status.
+// A kit.BusinessError anywhere in the wrap chain becomes a status with its Info as message,
+// its details and an ErrorInfo carrying the business code, Unknown when its code is kit.OK. Errors that already carry
+// a status keep it, context errors map to Canceled and DeadlineExceeded,
+// and any other error becomes Internal without leaking its message.
+func ToStatus(err error) *status.Status {
+	if err == nil {
+		return status.New(codes.OK, "")
+	}
+
+	var be kit.BusinessError
+	if errors.As(err, &be) {
+		return businessStatus(be)
+	}
+	if st, ok := status.FromError(err); ok {
+		return st
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return status.FromContextError(err)
+	}
+	return status.New(codes.Internal, kit.Messages[kit.ErrInternal])
+}
+
+// FromStatus converts a gRPC status back into a kit.Exception.
+// It returns nil for an OK status.
+func FromStatus(st *status.Status) *kit.Exception {
+	if st == nil || st.Code() == codes.OK {
+		return nil
+	}
+
+	code := KitCode(st.Code())
+	var details []kit.Detail
+	for _, d := range st.Details() {
+		if info, ok := d.(*errdetails.ErrorInfo); ok && info.GetDomain() == ErrorInfoDomain {
+			if c, err := strconv.Atoi(info.GetMetadata()[metadataCode]); err == nil {
+				code = c
+			}
+			continue
+		}
+		if detail, ok := fromProtoDetail(d); ok {
+			details = append(details, detail)
+		}
+	}
+
+	ex := kit.NewException().WithCode(code).WithInfo(st.Message())
+	if len(details) > 0 {
+		ex = ex.WithDetails(details...)
+	}
+	return ex
+}
+
+// FromError converts an error returned by a gRPC call into a kit.Exception.
+// It returns nil when err is nil.
+func FromError(err error) *kit.Exception {
+	if err == nil {
+		return nil
+	}
+	return FromStatus(status.Convert(err))
+}
+
+func businessStatus(be kit.BusinessError) *status.Status {
+	code := Code(be.Code())
+	if code == codes.OK {
+		// A non-nil error must not end a call as a success, such as an Exception whose code was never set.
+		code = codes.Unknown
+	}
+	st := status.New(code, be.Info())
+
+	protos := []protoadapt.MessageV1{
+		&errdetails.ErrorInfo{
+			Reason:   reasonBusinessCode,
+			Domain:   ErrorInfoDomain,
+			Metadata: map[string]string{metadataCode: strconv.Itoa(be.Code())},
+		},
+	}
+	if detailed, ok := be.(kit.DetailedError); ok {
+		for _, detail := range detailed.Details() {
+			if p, ok := toProtoDetail(detail); ok {
+				protos = append(protos, p)
+			}
+		}
+	}
+
+	withDetails, err := st.WithDetails(protos...)
+	if err != nil {
+		return st
+	}
+	return withDetails
+}
+
+func toProtoDetail(detail kit.Detail) (protoadapt.MessageV1, bool) {
+	switch d := detail.(type) {
+	case kit.BadRequest:
+		violations := make([]*errdetails.BadRequest_FieldViolation, 0, len(d.FieldViolations))
+		for _, v := range d.FieldViolations {
+			violations = append(violations, &errdetails.BadRequest_FieldViolation{Field: v.Field, Description: v.Description})
+		}
+		return &errdetails.BadRequest{FieldViolations: violations}, true
+	case kit.QuotaFailure:
+		violations := make([]*errdetails.QuotaFailure_Violation, 0, len(d.Violations))
+		for _, v := range d.Violations {
+			violations = append(violations, &errdetails.QuotaFailure_Violation{Subject: v.Subject, Description: v.Description})
+		}
+		return &errdetails.QuotaFailure{Violations: violations}, true
+	case kit.RetryInfo:
+		return &errdetails.RetryInfo{RetryDelay: durationpb.New(d.RetryDelay)}, true
+	case kit.ErrorInfo:
+		return &errdetails.ErrorInfo{Reason: d.Reason, Domain: d.Domain, Metadata: d.Metadata}, true
+	default:
+		return nil, false
+	}
+}
+
+func fromProtoDetail(detail any) (kit.Detail, bool) {
+	switch d := detail.(type) {
+	case *errdetails.BadRequest:
+		violations := make([]kit.FieldViolation, 0, len(d.GetFieldViolations()))
+		for _, v := range d.GetFieldViolations() {
+			violations = append(violations, kit.FieldViolation{Field: v.GetField(), Description: v.GetDescription()})
+		}
+		return kit.BadRequest{FieldViolations: violations}, true
+	case *errdetails.QuotaFailure:
+		violations := make([]kit.QuotaViolation, 0, len(d.GetViolations()))
+		for _, v := range d.GetViolations() {
+			violations = append(violations, kit.QuotaViolation{Subject: v.GetSubject(), Description: v.GetDescription()})
+		}
+		return kit.QuotaFailure{Violations: violations}, true
+	case *errdetails.RetryInfo:
+		return kit.RetryInfo{RetryDelay: d.GetRetryDelay().AsDuration()}, true
+	case *errdetails.ErrorInfo:
+		return kit.ErrorInfo{Reason: d.GetReason(), Domain: d.GetDomain(), Metadata: d.GetMetadata()}, true
+	default:
+		return nil, false
+	}
+}
@@ -0,0 +1,126 @@
+package kitgrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/qxsugar/pkg/kit"
+)
+
+func TestCode(t *testing.T) {
+	testCases := []struct {
+		name     string
+		code     int
+		expected codes.Code
+	}{
+		{"OK", kit.OK, codes.OK},
+		{"NotFound", kit.ErrNotFound, codes.NotFound},
+		{"NotImplemented", kit.ErrNotImplemented, codes.Unimplemented},
+		{"DeadlineExceeded", kit.ErrDeadlineExceeded, codes.DeadlineExceeded},
+		{"custom 400", 40010, codes.InvalidArgument},
+		{"custom 401", 40101, codes.Unauthenticated},
+		{"custom 403", 40301, codes.PermissionDenied},
+		{"custom 404", 40401, codes.NotFound},
+		{"custom 409", 40903, codes.Aborted},
+		{"custom 429", 42901, codes.ResourceExhausted},
+		{"custom 501", 50101, codes.Unimplemented},
+		{"custom 503", 50301, codes.Unavailable},
+		{"custom 504", 50401, codes.DeadlineExceeded},
+		{"other 4xx", 41000, codes.FailedPrecondition},
+		{"other 5xx", 50200, codes.Internal},
+		{"InternalErrorCode", kit.InternalErrorCode, codes.Internal},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, Code(tc.code))
+		})
+	}
+}
+
+func TestKitCode(t *testing.T) {
+	assert.Equal(t, kit.ErrNotFound, KitCode(codes.NotFound))
+	assert.Equal(t, kit.ErrNotImplemented, KitCode(codes.Unimplemented))
+	assert.Equal(t, kit.ErrUnknown, KitCode(codes.Code(100)))
+}
+
+func TestToStatus(t *testing.T) {
+	t.Run("nil", func(t *testing.T) {
+		assert.Equal(t, codes.OK, ToStatus(nil).Code())
+	})
+
+	t.Run("business error", func(t *testing.T) {
+		st := ToStatus(fmt.Errorf("wrapped: %w", kit.NewNotFoundError().WithInfo("user missing")))
+		assert.Equal(t, codes.NotFound, st.Code())
+		assert.Equal(t, "user missing", st.Message())
+		assert.Len(t, st.Details(), 1)
+	})
+
+	t.Run("business error with OK code", func(t *testing.T) {
+		st := ToStatus(kit.NewException().WithInfo("oops"))
+		assert.Equal(t, codes.Unknown, st.Code())
+		assert.Equal(t, "oops", st.Message())
+		assert.Error(t, st.Err())
+		assert.Equal(t, kit.OK, FromStatus(st).Code(), "the business code travels in the ErrorInfo")
+	})
+
+	t.Run("existing status", func(t *testing.T) {
+		st := ToStatus(status.Error(codes.Unavailable, "down"))
+		assert.Equal(t, codes.Unavailable, st.Code())
+		assert.Equal(t, "down", st.Message())
+	})
+
+	t.Run("context errors", func(t *testing.T) {
+		assert.Equal(t, codes.Canceled, ToStatus(context.Canceled).Code())
+		assert.Equal(t, codes.DeadlineExceeded, ToStatus(fmt.Errorf("query: %w", context.DeadlineExceeded)).Code())
+	})
+
+	t.Run("plain error does not leak", func(t *testing.T) {
+		st := ToStatus(errors.New("password=secret"))
+		assert.Equal(t, codes.Internal, st.Code())
+		assert.Equal(t, kit.Messages[kit.ErrInternal], st.Message())
+	})
+}
+
+func TestFromStatus(t *testing.T) {
+	t.Run("nil and OK", func(t *testing.T) {
+		assert.Nil(t, FromStatus(nil))
+		assert.Nil(t, FromStatus(status.New(codes.OK, "")))
+		assert.Nil(t, FromError(nil))
+	})
+
+	t.Run("round trip keeps custom code and details", func(t *testing.T) {
+		details := []kit.Detail{
+			kit.BadRequest{FieldViolations: []kit.FieldViolation{{Field: "coupon", Description: "expired"}}},
+			kit.QuotaFailure{Violations: []kit.QuotaViolation{{Subject: "user:1", Description: "daily limit"}}},
+			kit.RetryInfo{RetryDelay: 3 * time.Second},
+			kit.ErrorInfo{Reason: "COUPON_EXPIRED", Domain: "shop", Metadata: map[string]string{"coupon": "X1"}},
+		}
+		original := kit.NewException().WithCode(40010).WithInfo("Coupon expired").WithDetails(details...)
+
+		ex := FromError(ToStatus(original).Err())
+		assert.Equal(t, 40010, ex.Code())
+		assert.Equal(t, "Coupon expired", ex.Info())
+		assert.Equal(t, details, ex.Details())
+		assert.True(t, errors.Is(ex, original))
+	})
+
+	t.Run("foreign status maps the code", func(t *testing.T) {
+		ex := FromError(status.Error(codes.PermissionDenied, "no access"))
+		assert.Equal(t, kit.ErrPermissionDenied, ex.Code())
+		assert.Equal(t, "no access", ex.Info())
+		assert.Empty(t, ex.Details())
+	})
+
+	t.Run("plain error", func(t *testing.T) {
+		ex := FromError(errors.New("boom"))
+		assert.Equal(t, kit.ErrUnknown, ex.Code())
+	})
+}
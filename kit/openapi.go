@@ -0,0 +1,428 @@
+package kit
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/gin-gonic/gin"
+)
+
+// OpenAPIVersion is the version of the generated OpenAPI documents.
+const OpenAPIVersion = "3.1.0"
+
+// OpenAPIInfo is the info object of the generated OpenAPI document.
+type OpenAPIInfo struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+var (
+	ginParamPattern = regexp.MustCompile(`[:*]([^/]+)`)
+
+	timeType      = reflect.TypeOf(time.Time{})
+	timeStampType = reflect.TypeOf(TimeStamp{})
+	jsonType      = reflect.TypeOf(JSON{})
+	rawJSONType   = reflect.TypeOf(json.RawMessage{})
+	detailsType   = reflect.TypeOf(Details{})
+)
+
+// OpenAPI generates an OpenAPI 3.1 document describing the recorded routes.
+// Every success response is wrapped in the RespBody envelope, and error responses
+// list the business codes the route may return, grouped by the HTTP status and
+// described by the messages of the group's Translator.
+func (r *RouterGroup) OpenAPI(info OpenAPIInfo) map[string]any {
+	paths := map[string]map[string]any{}
+	for _, route := range r.Routes() {
+		openAPIPath := ginParamPattern.ReplaceAllString(route.Path, "{$1}")
+		if paths[openAPIPath] == nil {
+			paths[openAPIPath] = map[string]any{}
+		}
+		paths[openAPIPath][strings.ToLower(route.Method)] = operation(route, r.translator)
+	}
+
+	return map[string]any{
+		"openapi": OpenAPIVersion,
+		"info":    info,
+		"paths":   paths,
+		"components": map[string]any{
+			"schemas": map[string]any{
+				"RespBody": newSchemaBuilder().schema(reflect.TypeOf(RespBody{})),
+			},
+		},
+	}
+}
+
+// ServeOpenAPI registers a GET route serving the OpenAPI document of the group as JSON.
+// The document is generated on each request, so it includes routes registered later.
+func (r *RouterGroup) ServeOpenAPI(relativePath string, info OpenAPIInfo) *RouterGroup {
+	r.gin.GET(relativePath, func(ctx *gin.Context) {
+		ctx.JSON(http.StatusOK, r.OpenAPI(info))
+	})
+	return r
+}
+
+// OperationID returns the operation ID of the route, derived from the method and path
+// unless set in its RouteDoc, such as "getUsersId" for GET /users/:id.
+func (route RouteInfo) OperationID() string {
+	if route.Doc.OperationID != "" {
+		return route.Doc.OperationID
+	}
+
+	var b strings.Builder
+	b.WriteString(strings.ToLower(route.Method))
+	for _, word := range strings.FieldsFunc(route.Path, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+	return b.String()
+}
+
+// ResponseCodes returns the business codes the route may return when translated by translator,
+// including ErrInvalidArgument for routes with a request, and ErrInternal and the internal
+// error code of translator for every route.
+func (route RouteInfo) ResponseCodes(translator *Translator) []int {
+	seen := map[int]bool{ErrInternal: true, translator.internalCode: true}
+	if route.Request != nil {
+		seen[ErrInvalidArgument] = true
+	}
+	for _, code := range route.Doc.Codes {
+		seen[code] = true
+	}
+
+	codes := make([]int, 0, len(seen))
+	for code := range seen {
+		codes = append(codes, code)
+	}
+	sort.Ints(codes)
+	return codes
+}
+
+func operation(route RouteInfo, translator *Translator) map[string]any {
+	op := map[string]any{
+		"operationId": route.OperationID(),
+		"responses":   responses(route, translator),
+	}
+	if route.Doc.Summary != "" {
+		op["summary"] = route.Doc.Summary
+	}
+	if route.Doc.Description != "" {
+		op["description"] = route.Doc.Description
+	}
+	if len(route.Doc.Tags) > 0 {
+		op["tags"] = route.Doc.Tags
+	}
+
+	var params []any
+	var body map[string]any
+	if route.Request != nil {
+		params, body = requestSchema(route.Request)
+	}
+	params = append(pathParameters(route.Path, params), params...)
+	if len(params) > 0 {
+		op["parameters"] = params
+	}
+	if body != nil && hasRequestBody(route.Method) {
+		op["requestBody"] = map[string]any{
+			"required": true,
+			"content":  map[string]any{"application/json": map[string]any{"schema": body}},
+		}
+	}
+	return op
+}
+
+// pathParameters describes the parameters of path which are not in params as strings,
+// such as those of routes without a request type.
+func pathParameters(path string, params []any) []any {
+	described := map[string]bool{}
+	for _, param := range params {
+		if param := param.(map[string]any); param["in"] == "path" {
+			described[param["name"].(string)] = true
+		}
+	}
+
+	var missing []any
+	for _, match := range ginParamPattern.FindAllStringSubmatch(path, -1) {
+		if described[match[1]] {
+			continue
+		}
+		missing = append(missing, map[string]any{
+			"name":     match[1],
+			"in":       "path",
+			"required": true,
+			"schema":   map[string]any{"type": "string"},
+		})
+	}
+	return missing
+}
+
+func hasRequestBody(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodDelete, http.MethodOptions, http.MethodTrace:
+		return false
+	}
+	return true
+}
+
+func responses(route RouteInfo, translator *Translator) map[string]any {
+	status := translator.getStatus()
+	respData := map[string]any{}
+	if route.Response != nil {
+		respData = newSchemaBuilder().schema(route.Response)
+	}
+
+	result := map[string]any{
+		strconv.Itoa(status.Status(OK)): map[string]any{
+			"description": "Succeeded",
+			"content": map[string]any{"application/json": map[string]any{"schema": map[string]any{
+				"allOf": []any{
+					map[string]any{"$ref": "#/components/schemas/RespBody"},
+					map[string]any{"properties": map[string]any{"resp_data": respData}},
+				},
+			}}},
+		},
+	}
+
+	byStatus := map[int][]int{}
+	for _, code := range route.ResponseCodes(translator) {
+		httpStatus := status.Status(code)
+		byStatus[httpStatus] = append(byStatus[httpStatus], code)
+	}
+	for httpStatus, codes := range byStatus {
+		descriptions := make([]string, 0, len(codes))
+		businessCodes := make([]any, 0, len(codes))
+		for _, code := range codes {
+			message := codeMessage(translator, code)
+			descriptions = append(descriptions, fmt.Sprintf("%d: %s", code, message))
+			businessCodes = append(businessCodes, map[string]any{"code": code, "message": message})
+		}
+
+		response := map[string]any{
+			"description":      strings.Join(descriptions, "; "),
+			"x-business-codes": businessCodes,
+			"content": map[string]any{"application/json": map[string]any{
+				"schema": map[string]any{"$ref": "#/components/schemas/RespBody"},
+			}},
+		}
+		// Errors share the success status when the legacy "always 200" mapping is used.
+		if existing, ok := result[strconv.Itoa(httpStatus)].(map[string]any); ok {
+			existing["x-business-codes"] = businessCodes
+			existing["description"] = existing["description"].(string) + "; " + response["description"].(string)
+			continue
+		}
+		result[strconv.Itoa(httpStatus)] = response
+	}
+	return result
+}
+
+func codeMessage(translator *Translator, code int) string {
+	if code == translator.internalCode {
+		// Errors that are not a BusinessError are reported with the message of ErrInternal.
+		code = ErrInternal
+	}
+	catalog := translator.getCatalog()
+	if message, ok := catalog.Message(catalog.defaultLocale, code); ok {
+		return message
+	}
+	info, _ := DefaultCodeRegistry.Lookup(code)
+	return info.Message
+}
+
+// requestSchema splits the request into uri, query and header parameters
+// and a JSON body schema, mirroring BindRequest.
+func requestSchema(t reflect.Type) ([]any, map[string]any) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, newSchemaBuilder().schema(t)
+	}
+
+	builder := newSchemaBuilder()
+	var params []any
+	properties := map[string]any{}
+	var required []string
+	for _, field := range structFields(t) {
+		isRequired := hasBindingRule(field, "required")
+		schema := builder.schema(field.Type)
+
+		location, name := parameterLocation(field)
+		if location != "" {
+			params = append(params, map[string]any{
+				"name":     name,
+				"in":       location,
+				"required": location == "path" || isRequired,
+				"schema":   schema,
+			})
+			continue
+		}
+
+		name, ok := jsonFieldName(field)
+		if !ok {
+			continue
+		}
+		properties[name] = schema
+		if isRequired {
+			required = append(required, name)
+		}
+	}
+
+	if len(properties) == 0 {
+		return params, nil
+	}
+	body := map[string]any{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		body["required"] = required
+	}
+	return params, body
+}
+
+func parameterLocation(field reflect.StructField) (string, string) {
+	for _, source := range []struct{ tag, location string }{
+		{"uri", "path"},
+		{"form", "query"},
+		{"header", "header"},
+	} {
+		if name, _, _ := strings.Cut(field.Tag.Get(source.tag), ","); name != "" && name != "-" {
+			return source.location, name
+		}
+	}
+	return "", ""
+}
+
+func hasBindingRule(field reflect.StructField, rule string) bool {
+	for _, r := range strings.Split(field.Tag.Get("binding"), ",") {
+		if r == rule {
+			return true
+		}
+	}
+	return false
+}
+
+func jsonFieldName(field reflect.StructField) (string, bool) {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	switch name {
+	case "-":
+		return "", false
+	case "":
+		return field.Name, true
+	}
+	return name, true
+}
+
+// structFields returns the exported fields of t, flattening embedded structs without a json name.
+func structFields(t reflect.Type) []reflect.StructField {
+	var fields []reflect.StructField
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Anonymous && field.Tag.Get("json") == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				for _, f := range structFields(embedded) {
+					f.Index = append([]int{i}, f.Index...)
+					fields = append(fields, f)
+				}
+				continue
+			}
+		}
+		if field.IsExported() {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+type schemaBuilder struct {
+	visiting map[reflect.Type]bool
+}
+
+func newSchemaBuilder() *schemaBuilder {
+	return &schemaBuilder{visiting: map[reflect.Type]bool{}}
+}
+
+// schema describes t as a JSON schema. Interface types are described by an empty schema.
+func (b *schemaBuilder) schema(t reflect.Type) map[string]any {
+	switch t {
+	case timeType:
+		return map[string]any{"type": "string", "format": "date-time"}
+	case timeStampType:
+		return map[string]any{"type": "integer", "format": "int64", "description": "Unix timestamp"}
+	case jsonType, rawJSONType:
+		return map[string]any{}
+	case detailsType:
+		return map[string]any{"type": "array", "items": map[string]any{
+			"type":       "object",
+			"properties": map[string]any{"@type": map[string]any{"type": "string"}},
+			"required":   []string{"@type"},
+		}}
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		return b.schema(t.Elem())
+	case reflect.Interface:
+		return map[string]any{}
+	case reflect.Struct:
+		return b.structSchema(t)
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]any{"type": "string", "format": "byte"}
+		}
+		return map[string]any{"type": "array", "items": b.schema(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": b.schema(t.Elem())}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16:
+		return map[string]any{"type": "integer", "format": "int32"}
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer", "format": "int64"}
+	case reflect.Float32:
+		return map[string]any{"type": "number", "format": "float"}
+	case reflect.Float64:
+		return map[string]any{"type": "number", "format": "double"}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	default:
+		return map[string]any{}
+	}
+}
+
+func (b *schemaBuilder) structSchema(t reflect.Type) map[string]any {
+	if b.visiting[t] {
+		return map[string]any{"type": "object"}
+	}
+	b.visiting[t] = true
+	defer delete(b.visiting, t)
+
+	properties := map[string]any{}
+	var required []string
+	for _, field := range structFields(t) {
+		name, ok := jsonFieldName(field)
+		if !ok {
+			continue
+		}
+		properties[name] = b.schema(field.Type)
+		if hasBindingRule(field, "required") {
+			required = append(required, name)
+		}
+	}
+
+	schema := map[string]any{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
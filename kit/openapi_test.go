@@ -0,0 +1,312 @@
+package kit
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type openAPIUser struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	Tags      []string  `json:"tags,omitempty"`
+	CreatedAt TimeStamp `json:"created_at"`
+	Secret    string    `json:"-"`
+	Parent    *openAPIUser
+}
+
+type openAPIPaging struct {
+	Offset int `form:"offset"`
+}
+
+type openAPIListReq struct {
+	openAPIPaging
+	Keyword string `form:"keyword" binding:"required"`
+}
+
+type openAPICreateReq struct {
+	OrgID     string `uri:"org_id"`
+	RequestID string `header:"X-Request-Id"`
+	Name      string `json:"name" binding:"required"`
+	Age       int    `json:"age"`
+}
+
+func setupOpenAPIGroup() (*gin.Engine, *RouterGroup) {
+	r := gin.New()
+	root := NewRouterGroup(&r.RouterGroup)
+	api := root.Group("/api")
+	api.Doc(RouteDoc{
+		Summary: "List users",
+		Tags:    []string{"users"},
+	}).GET("/users", Handle(func(ctx *gin.Context, req *openAPIListReq) (PageBodyOf[openAPIUser], error) {
+		return PageBodyOf[openAPIUser]{}, nil
+	}))
+	api.Group("/orgs").Doc(RouteDoc{
+		OperationID: "createUser",
+		Codes:       []int{ErrAlreadyExists, ErrAborted},
+	}).POST("/:org_id/users", Handle(func(ctx *gin.Context, req *openAPICreateReq) (*openAPIUser, error) {
+		return nil, nil
+	}))
+	api.GET("/ping", Pong)
+	return r, root
+}
+
+func TestRouterGroup_Routes(t *testing.T) {
+	_, root := setupOpenAPIGroup()
+
+	routes := root.Routes()
+	assert.Len(t, routes, 3)
+	assert.Equal(t, http.MethodGet, routes[0].Method)
+	assert.Equal(t, "/api/users", routes[0].Path)
+	assert.Equal(t, "List users", routes[0].Doc.Summary)
+	assert.Equal(t, reflect.TypeFor[openAPIListReq](), routes[0].Request)
+	assert.Equal(t, reflect.TypeFor[PageBodyOf[openAPIUser]](), routes[0].Response)
+	assert.Equal(t, "/api/orgs/:org_id/users", routes[1].Path)
+	assert.Equal(t, reflect.TypeFor[*openAPIUser](), routes[1].Response)
+	assert.Equal(t, "createUser", routes[1].OperationID())
+	assert.Equal(t, "/api/ping", routes[2].Path)
+	assert.Equal(t, RouteDoc{}, routes[2].Doc, "doc only applies to one route")
+	assert.Nil(t, routes[2].Request, "plain handlers have no request type")
+	assert.Nil(t, routes[2].Response)
+	assert.Equal(t, "getApiPing", routes[2].OperationID())
+	assert.Equal(t, []int{InternalErrorCode, ErrInvalidArgument, ErrAborted, ErrAlreadyExists, ErrInternal},
+		routes[1].ResponseCodes(DefaultTranslator))
+}
+
+func TestRouterGroup_RouteTypes(t *testing.T) {
+	r := gin.New()
+	group := NewRouterGroup(&r.RouterGroup)
+
+	called := false
+	group.GET("/plain", func(ctx *gin.Context) (any, error) { called = true; return nil, nil })
+	group.DELETE("/empty", Handle(func(ctx *gin.Context, req *struct{}) (RowAffectedBody, error) {
+		return RowAffectedBody{Rows: 1}, nil
+	}))
+	group.GET("/wrapped", Handle(func(ctx *gin.Context, req *openAPIListReq) (openAPIUser, error) {
+		return openAPIUser{Name: req.Keyword}, nil
+	}), func(next HandlerFunc) HandlerFunc { return next })
+
+	routes := group.Routes()
+	assert.False(t, called, "plain handlers are not called at registration")
+	assert.Nil(t, routes[0].Request)
+	assert.Nil(t, routes[1].Request, "an empty struct carries no request")
+	assert.Equal(t, reflect.TypeFor[RowAffectedBody](), routes[1].Response)
+	assert.Equal(t, reflect.TypeFor[openAPIListReq](), routes[2].Request)
+	assert.Equal(t, reflect.TypeFor[openAPIUser](), routes[2].Response)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/wrapped?keyword=alice", http.NoBody)
+	r.ServeHTTP(w, req)
+	respBody := RespBody{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &respBody))
+	assert.Equal(t, "alice", respBody.RespData.(map[string]any)["name"])
+}
+
+func TestRouterGroup_DocWithMatch(t *testing.T) {
+	r := gin.New()
+	group := NewRouterGroup(&r.RouterGroup)
+	group.Doc(RouteDoc{Summary: "Status"}).Match([]string{http.MethodGet, http.MethodHead}, "/status", Pong)
+
+	routes := group.Routes()
+	assert.Len(t, routes, 2)
+	assert.Equal(t, "Status", routes[0].Doc.Summary)
+	assert.Equal(t, "Status", routes[1].Doc.Summary)
+}
+
+func TestRouterGroup_OpenAPI(t *testing.T) {
+	_, root := setupOpenAPIGroup()
+
+	data, err := json.Marshal(root.OpenAPI(OpenAPIInfo{Title: "Users", Version: "1.0.0"}))
+	assert.NoError(t, err)
+
+	var doc map[string]any
+	assert.NoError(t, json.Unmarshal(data, &doc))
+	assert.Equal(t, "3.1.0", doc["openapi"])
+	assert.Equal(t, map[string]any{"title": "Users", "version": "1.0.0"}, doc["info"])
+
+	paths := doc["paths"].(map[string]any)
+	assert.Len(t, paths, 3)
+
+	t.Run("query parameters and paginated response", func(t *testing.T) {
+		op := paths["/api/users"].(map[string]any)["get"].(map[string]any)
+		assert.Equal(t, "List users", op["summary"])
+		assert.Equal(t, []any{"users"}, op["tags"])
+		assert.Nil(t, op["requestBody"])
+		assert.Equal(t, []any{
+			map[string]any{"name": "offset", "in": "query", "required": false,
+				"schema": map[string]any{"type": "integer", "format": "int64"}},
+			map[string]any{"name": "keyword", "in": "query", "required": true,
+				"schema": map[string]any{"type": "string"}},
+		}, op["parameters"])
+
+		responses := op["responses"].(map[string]any)
+		success := responses["200"].(map[string]any)["content"].(map[string]any)["application/json"].(map[string]any)
+		allOf := success["schema"].(map[string]any)["allOf"].([]any)
+		assert.Equal(t, map[string]any{"$ref": "#/components/schemas/RespBody"}, allOf[0])
+
+		respData := allOf[1].(map[string]any)["properties"].(map[string]any)["resp_data"].(map[string]any)
+		list := respData["properties"].(map[string]any)["list"].(map[string]any)
+		assert.Equal(t, "array", list["type"])
+		user := list["items"].(map[string]any)["properties"].(map[string]any)
+		assert.Equal(t, map[string]any{"type": "integer", "format": "int64"}, user["id"])
+		assert.Equal(t, map[string]any{"type": "integer", "format": "int64", "description": "Unix timestamp"}, user["created_at"])
+		assert.Equal(t, map[string]any{"type": "object"}, user["Parent"], "recursive types are cut")
+		assert.NotContains(t, user, "Secret")
+
+		assert.Contains(t, responses, "400")
+		assert.Contains(t, responses, "500")
+	})
+
+	t.Run("path, header and body", func(t *testing.T) {
+		op := paths["/api/orgs/{org_id}/users"].(map[string]any)["post"].(map[string]any)
+		assert.Equal(t, "createUser", op["operationId"])
+		assert.Equal(t, []any{
+			map[string]any{"name": "org_id", "in": "path", "required": true, "schema": map[string]any{"type": "string"}},
+			map[string]any{"name": "X-Request-Id", "in": "header", "required": false, "schema": map[string]any{"type": "string"}},
+		}, op["parameters"])
+
+		body := op["requestBody"].(map[string]any)["content"].(map[string]any)["application/json"].(map[string]any)["schema"]
+		assert.Equal(t, map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"name": map[string]any{"type": "string"},
+				"age":  map[string]any{"type": "integer", "format": "int64"},
+			},
+			"required": []any{"name"},
+		}, body)
+
+		conflict := op["responses"].(map[string]any)["409"].(map[string]any)
+		assert.Equal(t, "40901: Operation aborted; 40902: Resource already exists", conflict["description"])
+		assert.Equal(t, []any{
+			map[string]any{"code": float64(ErrAborted), "message": Messages[ErrAborted]},
+			map[string]any{"code": float64(ErrAlreadyExists), "message": Messages[ErrAlreadyExists]},
+		}, conflict["x-business-codes"])
+	})
+
+	t.Run("RespBody component", func(t *testing.T) {
+		schemas := doc["components"].(map[string]any)["schemas"].(map[string]any)
+		properties := schemas["RespBody"].(map[string]any)["properties"].(map[string]any)
+		assert.Equal(t, map[string]any{"type": "boolean"}, properties["succeeded"])
+		assert.Equal(t, map[string]any{}, properties["resp_data"])
+		assert.Equal(t, "array", properties["details"].(map[string]any)["type"])
+	})
+}
+
+func TestRouterGroup_OpenAPIAlwaysOK(t *testing.T) {
+	original := DefaultStatusMapping
+	DefaultStatusMapping = &StatusMapping{AlwaysOK: true}
+	defer func() { DefaultStatusMapping = original }()
+
+	r := gin.New()
+	group := NewRouterGroup(&r.RouterGroup)
+	group.Doc(RouteDoc{Codes: []int{ErrNotFound}}).GET("/users/:id", Pong)
+
+	responses := group.OpenAPI(OpenAPIInfo{})["paths"].(map[string]map[string]any)["/users/{id}"]["get"].(map[string]any)["responses"].(map[string]any)
+	assert.Len(t, responses, 1)
+	assert.Contains(t, responses["200"].(map[string]any)["description"], "40400: Resource does not exist")
+}
+
+func TestRouterGroup_OpenAPIPathParameters(t *testing.T) {
+	type getFileReq struct {
+		ID string `uri:"id"`
+	}
+
+	r := gin.New()
+	group := NewRouterGroup(&r.RouterGroup)
+	group.GET("/users/:id", Pong)
+	group.GET("/users/:id/files/*path", Handle(func(ctx *gin.Context, req *getFileReq) (any, error) {
+		return nil, nil
+	}))
+
+	paths := group.OpenAPI(OpenAPIInfo{})["paths"].(map[string]map[string]any)
+	assert.Equal(t, []any{
+		map[string]any{"name": "id", "in": "path", "required": true, "schema": map[string]any{"type": "string"}},
+	}, paths["/users/{id}"]["get"].(map[string]any)["parameters"], "routes without a request type")
+	assert.Equal(t, []any{
+		map[string]any{"name": "path", "in": "path", "required": true, "schema": map[string]any{"type": "string"}},
+		map[string]any{"name": "id", "in": "path", "required": true, "schema": map[string]any{"type": "string"}},
+	}, paths["/users/{id}/files/{path}"]["get"].(map[string]any)["parameters"], "parameters without a uri field")
+}
+
+func TestRouterGroup_OpenAPITranslator(t *testing.T) {
+	catalog := NewCatalog("en")
+	catalog.Register("en", map[int]string{ErrNotFound: "No such user"})
+	translator := NewTranslator(WithStatusMapping(&StatusMapping{AlwaysOK: true}), WithCatalog(catalog))
+
+	r := gin.New()
+	group := NewRouterGroupWithTranslator(&r.RouterGroup, translator)
+	group.Doc(RouteDoc{Codes: []int{ErrNotFound, ErrAlreadyExists}}).GET("/users/:id", Pong)
+
+	responses := group.OpenAPI(OpenAPIInfo{})["paths"].(map[string]map[string]any)["/users/{id}"]["get"].(map[string]any)["responses"].(map[string]any)
+	assert.Len(t, responses, 1)
+	description := responses["200"].(map[string]any)["description"]
+	assert.Contains(t, description, "40400: No such user")
+	assert.Contains(t, description, "40902: "+Messages[ErrAlreadyExists])
+}
+
+func TestRouterGroup_OpenAPIInternalErrorCode(t *testing.T) {
+	translator := NewTranslator(WithInternalErrorCode(ErrUnavailable))
+
+	r := gin.New()
+	group := NewRouterGroupWithTranslator(&r.RouterGroup, translator)
+	group.GET("/users", Pong)
+
+	routes := group.Routes()
+	assert.Equal(t, []int{ErrInternal, ErrUnavailable}, routes[0].ResponseCodes(translator))
+
+	responses := group.OpenAPI(OpenAPIInfo{})["paths"].(map[string]map[string]any)["/users"]["get"].(map[string]any)["responses"].(map[string]any)
+	unavailable := responses[strconv.Itoa(DefaultStatusMapping.Status(ErrUnavailable))].(map[string]any)
+	assert.Equal(t, []any{
+		map[string]any{"code": ErrUnavailable, "message": Messages[ErrInternal]},
+	}, unavailable["x-business-codes"], "plain errors are described by the message of ErrInternal")
+}
+
+func TestRouterGroup_ServeOpenAPI(t *testing.T) {
+	r, root := setupOpenAPIGroup()
+	root.ServeOpenAPI("/openapi.json", OpenAPIInfo{Title: "Users", Version: "1.0.0"})
+	root.GET("/late", Pong)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/openapi.json", http.NoBody)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var doc map[string]any
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &doc))
+	assert.Equal(t, "3.1.0", doc["openapi"])
+	assert.Contains(t, doc["paths"], "/late")
+	assert.NotContains(t, doc["paths"], "/openapi.json")
+}
+
+func TestSchemaBuilder(t *testing.T) {
+	builder := newSchemaBuilder()
+	var nilAny any
+
+	testCases := []struct {
+		name     string
+		value    any
+		expected map[string]any
+	}{
+		{"bytes", []byte("x"), map[string]any{"type": "string", "format": "byte"}},
+		{"map", map[string]bool{}, map[string]any{"type": "object", "additionalProperties": map[string]any{"type": "boolean"}}},
+		{"float32", float32(1), map[string]any{"type": "number", "format": "float"}},
+		{"float64", 1.5, map[string]any{"type": "number", "format": "double"}},
+		{"int8", int8(1), map[string]any{"type": "integer", "format": "int32"}},
+		{"JSON", JSON(`{}`), map[string]any{}},
+		{"nil pointer", (*string)(nil), map[string]any{"type": "string"}},
+		{"interface slice", []any{nilAny}, map[string]any{"type": "array", "items": map[string]any{}}},
+		{"chan", make(chan int), map[string]any{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, builder.schema(reflect.TypeOf(tc.value)))
+		})
+	}
+}
@@ -0,0 +1,260 @@
+package kit
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PageLimits bounds the number of items a list endpoint returns.
+type PageLimits struct {
+	Default int // Limit used when the request has none, DefaultPageLimits.Default capped to Max when not positive
+	Max     int // Larger limits are clamped to Max
+}
+
+// DefaultPageLimits are the limits used when a zero PageLimits is given.
+var DefaultPageLimits = PageLimits{Default: 20, Max: 100}
+
+// normalize returns the limits to apply, so that the default limit is always positive.
+func (l PageLimits) normalize() PageLimits {
+	if l == (PageLimits{}) {
+		return DefaultPageLimits
+	}
+	if l.Default <= 0 {
+		l.Default = DefaultPageLimits.Default
+		if l.Max > 0 {
+			l.Default = min(l.Default, l.Max)
+		}
+	}
+	return l
+}
+
+// ParseOffsetPage reads the offset and limit query parameters, it is ParsePageRequest
+// with clamped values and without sort: a missing or non-positive limit becomes limits.Default,
+// a larger one is clamped to limits.Max, and a negative offset becomes 0.
+// Non-numeric values are reported as ErrInvalidArgument.
+func ParseOffsetPage(ctx *gin.Context, limits PageLimits) (offset, limit int, err error) {
+	page, err := ParsePageRequest(ctx, PageRules{Limits: limits, Clamp: true})
+	if err != nil {
+		return 0, 0, err
+	}
+	return page.Offset, page.Limit, nil
+}
+
+// NewPageBody builds a PageBody from the items of one page, a nil slice is written as an empty list.
+func NewPageBody[T any](items []T, offset, limit int, total int64) PageBody {
+	if items == nil {
+		items = []T{}
+	}
+	return PageBody{Offset: offset, Limit: limit, Total: total, List: items}
+}
+
+// SortOrder is a validated sort criterion of a PageRequest.
+type SortOrder struct {
+	Field  string // Field name used in the query string
+	Column string // SQL column the field maps to
+	Desc   bool   // Whether the order is descending
+}
+
+// PageRules configures ParsePageRequest.
+type PageRules struct {
+	Limits      PageLimits        // Default and maximum limits, DefaultPageLimits when zero
+	MaxOffset   int               // Largest accepted offset, unlimited when zero
+	Sortable    map[string]string // Whitelist of sortable fields, mapped to their trusted SQL column, sort is ignored when nil
+	DefaultSort string            // Sort applied when the request has none, in the syntax of the sort parameter
+	Clamp       bool              // Clamp out-of-range offsets and limits instead of rejecting them
+}
+
+// PageRequest is a validated offset page request.
+type PageRequest struct {
+	Offset int         // Number of items to skip
+	Limit  int         // Number of items of the page
+	Sort   []SortOrder // Sort criteria, in priority order
+}
+
+// ParsePageRequest reads the offset, limit and sort query parameters, such as
+// ?offset=40&limit=20&sort=-created_at,name where a leading - sorts descending.
+// A missing limit becomes rules.Limits.Default. Negative values, limits above the maximum
+// and offsets above rules.MaxOffset are reported as ErrOutOfRange, unless rules.Clamp is set,
+// in which case they are clamped like in ParseOffsetPage. Non-numeric values and fields
+// missing from rules.Sortable are reported as ErrInvalidArgument.
+func ParsePageRequest(ctx *gin.Context, rules PageRules) (PageRequest, error) {
+	offset, err := parseOffset(ctx, rules)
+	if err != nil {
+		return PageRequest{}, err
+	}
+
+	var limit int
+	if rules.Clamp {
+		limit, err = parseLimit(ctx, rules.Limits)
+	} else {
+		limit, err = parseStrictLimit(ctx, rules.Limits)
+	}
+	if err != nil {
+		return PageRequest{}, err
+	}
+
+	if rules.Sortable == nil {
+		return PageRequest{Offset: offset, Limit: limit}, nil
+	}
+	sort := strings.Join(ctx.QueryArray("sort"), ",")
+	if sort == "" {
+		sort = rules.DefaultSort
+	}
+	orders, err := parseSort(sort, rules.Sortable)
+	if err != nil {
+		return PageRequest{}, err
+	}
+	return PageRequest{Offset: offset, Limit: limit, Sort: orders}, nil
+}
+
+// OrderBy returns the ORDER BY clause of the request, or an empty string when it has no sort.
+// Only whitelisted columns reach the clause, so it is safe to concatenate into a query.
+func (p PageRequest) OrderBy() string {
+	if len(p.Sort) == 0 {
+		return ""
+	}
+	terms := make([]string, 0, len(p.Sort))
+	for _, order := range p.Sort {
+		direction := "ASC"
+		if order.Desc {
+			direction = "DESC"
+		}
+		terms = append(terms, order.Column+" "+direction)
+	}
+	return "ORDER BY " + strings.Join(terms, ", ")
+}
+
+// LimitOffset returns the LIMIT and OFFSET clause of the request.
+func (p PageRequest) LimitOffset() string {
+	return fmt.Sprintf("LIMIT %d OFFSET %d", p.Limit, p.Offset)
+}
+
+// Suffix returns the ORDER BY, LIMIT and OFFSET clauses to append to a SELECT statement.
+func (p PageRequest) Suffix() string {
+	if orderBy := p.OrderBy(); orderBy != "" {
+		return orderBy + " " + p.LimitOffset()
+	}
+	return p.LimitOffset()
+}
+
+// parseSort parses a comma separated list of fields, each optionally prefixed with - for a
+// descending order or suffixed with :asc or :desc, but not both.
+func parseSort(sort string, sortable map[string]string) ([]SortOrder, error) {
+	var orders []SortOrder
+	seen := map[string]bool{}
+	for _, term := range strings.Split(sort, ",") {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			continue
+		}
+
+		field, direction, hasDirection := strings.Cut(term, ":")
+		desc := strings.HasPrefix(field, "-")
+		field = strings.TrimPrefix(field, "-")
+		if hasDirection {
+			switch strings.ToLower(direction) {
+			case "asc", "desc":
+				if desc {
+					return nil, invalidQuery("sort", fmt.Errorf("sort term %q mixes - and a direction", term))
+				}
+				desc = strings.EqualFold(direction, "desc")
+			default:
+				return nil, invalidQuery("sort", fmt.Errorf("sort direction %q must be asc or desc", direction))
+			}
+		}
+
+		column, ok := sortable[field]
+		if !ok {
+			return nil, invalidQuery("sort", fmt.Errorf("field %q is not sortable", field))
+		}
+		if seen[field] {
+			return nil, invalidQuery("sort", fmt.Errorf("field %q is sorted twice", field))
+		}
+		seen[field] = true
+		orders = append(orders, SortOrder{Field: field, Column: column, Desc: desc})
+	}
+	return orders, nil
+}
+
+func parseOffset(ctx *gin.Context, rules PageRules) (int, error) {
+	offset, err := queryInt(ctx, "offset")
+	if err != nil {
+		return 0, err
+	}
+	if offset < 0 {
+		if !rules.Clamp {
+			return 0, outOfRangeQuery("offset", errors.New("offset must not be negative"))
+		}
+		offset = 0
+	}
+	if rules.MaxOffset > 0 && offset > rules.MaxOffset {
+		if !rules.Clamp {
+			return 0, outOfRangeQuery("offset", fmt.Errorf("offset must not exceed %d", rules.MaxOffset))
+		}
+		offset = rules.MaxOffset
+	}
+	return offset, nil
+}
+
+func parseStrictLimit(ctx *gin.Context, limits PageLimits) (int, error) {
+	limits = limits.normalize()
+	limit, err := queryInt(ctx, "limit")
+	if err != nil {
+		return 0, err
+	}
+	if limit == 0 {
+		limit = limits.Default
+	}
+	if limit < 0 {
+		return 0, outOfRangeQuery("limit", errors.New("limit must not be negative"))
+	}
+	if limits.Max > 0 && limit > limits.Max {
+		return 0, outOfRangeQuery("limit", fmt.Errorf("limit must not exceed %d", limits.Max))
+	}
+	return limit, nil
+}
+
+// parseLimit clamps the limit query parameter into limits.
+func parseLimit(ctx *gin.Context, limits PageLimits) (int, error) {
+	limits = limits.normalize()
+	limit, err := queryInt(ctx, "limit")
+	if err != nil {
+		return 0, err
+	}
+	if limit <= 0 {
+		limit = limits.Default
+	}
+	if limits.Max > 0 && limit > limits.Max {
+		limit = limits.Max
+	}
+	return limit, nil
+}
+
+func queryInt(ctx *gin.Context, key string) (int, error) {
+	value := ctx.Query(key)
+	if value == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, invalidQuery(key, fmt.Errorf("%s must be an integer", key))
+	}
+	return n, nil
+}
+
+func outOfRangeQuery(key string, err error) *Exception {
+	return NewOutOfRangeError().
+		WithErr(err).
+		WithDetails(BadRequest{FieldViolations: []FieldViolation{{Field: key, Description: err.Error()}}})
+}
+
+// invalidQuery reports an invalid query parameter the way BindRequest reports invalid fields.
+func invalidQuery(key string, err error) *Exception {
+	return NewInvalidArgumentError().
+		WithErr(err).
+		WithDetails(BadRequest{FieldViolations: []FieldViolation{{Field: key, Description: err.Error()}}})
+}
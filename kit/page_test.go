@@ -0,0 +1,158 @@
+package kit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func newQueryContext(query string) *gin.Context {
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	ctx.Request, _ = http.NewRequest(http.MethodGet, "/items?"+query, http.NoBody)
+	return ctx
+}
+
+func TestParseOffsetPage(t *testing.T) {
+	testCases := []struct {
+		name   string
+		query  string
+		limits PageLimits
+		offset int
+		limit  int
+		err    bool
+	}{
+		{name: "defaults", query: "", offset: 0, limit: 20},
+		{name: "values", query: "offset=40&limit=10", offset: 40, limit: 10},
+		{name: "clamped limit", query: "limit=1000", offset: 0, limit: 100},
+		{name: "custom limits", query: "limit=1000", limits: PageLimits{Default: 5, Max: 50}, limit: 50},
+		{name: "custom default", query: "limit=0", limits: PageLimits{Default: 5, Max: 50}, limit: 5},
+		{name: "missing default", query: "", limits: PageLimits{Max: 50}, limit: 20},
+		{name: "missing default below max", query: "", limits: PageLimits{Default: -1, Max: 8}, limit: 8},
+		{name: "negative offset", query: "offset=-3&limit=-1", offset: 0, limit: 20},
+		{name: "sort is ignored", query: "offset=5&sort=password", offset: 5, limit: 20},
+		{name: "invalid offset", query: "offset=abc", err: true},
+		{name: "invalid limit", query: "limit=1.5", err: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			offset, limit, err := ParseOffsetPage(newQueryContext(tc.query), tc.limits)
+			if tc.err {
+				assert.ErrorIs(t, err, NewInvalidArgumentError())
+				assert.Len(t, err.(*Exception).Details(), 1)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.offset, offset)
+			assert.Equal(t, tc.limit, limit)
+		})
+	}
+}
+
+func TestNewPageBody(t *testing.T) {
+	assert.Equal(t, PageBody{Offset: 2, Limit: 2, Total: 5, List: []int{3, 4}}, NewPageBody([]int{3, 4}, 2, 2, 5))
+	assert.Equal(t, PageBody{Offset: 0, Limit: 20, Total: 0, List: []string{}}, NewPageBody([]string(nil), 0, 20, 0))
+}
+
+func TestParsePageRequest(t *testing.T) {
+	rules := PageRules{
+		Limits:      PageLimits{Default: 10, Max: 50},
+		MaxOffset:   1000,
+		Sortable:    map[string]string{"created_at": "o.created_at", "name": "u.name", "id": "o.id"},
+		DefaultSort: "-created_at",
+	}
+
+	testCases := []struct {
+		name    string
+		query   string
+		want    PageRequest
+		code    int
+		message string
+	}{
+		{
+			name:  "defaults",
+			query: "",
+			want:  PageRequest{Offset: 0, Limit: 10, Sort: []SortOrder{{Field: "created_at", Column: "o.created_at", Desc: true}}},
+		},
+		{
+			name:  "values",
+			query: "offset=20&limit=50&sort=name,-id",
+			want: PageRequest{Offset: 20, Limit: 50, Sort: []SortOrder{
+				{Field: "name", Column: "u.name"},
+				{Field: "id", Column: "o.id", Desc: true},
+			}},
+		},
+		{
+			name:  "direction suffix and repeated parameter",
+			query: "sort=name:DESC&sort=id:asc",
+			want: PageRequest{Offset: 0, Limit: 10, Sort: []SortOrder{
+				{Field: "name", Column: "u.name", Desc: true},
+				{Field: "id", Column: "o.id"},
+			}},
+		},
+		{name: "negative offset", query: "offset=-1", code: ErrOutOfRange, message: "offset must not be negative"},
+		{name: "offset too large", query: "offset=1001", code: ErrOutOfRange, message: "offset must not exceed 1000"},
+		{name: "negative limit", query: "limit=-5", code: ErrOutOfRange, message: "limit must not be negative"},
+		{name: "limit too large", query: "limit=51", code: ErrOutOfRange, message: "limit must not exceed 50"},
+		{name: "non-numeric limit", query: "limit=ten", code: ErrInvalidArgument, message: "limit must be an integer"},
+		{name: "unknown field", query: "sort=password", code: ErrInvalidArgument, message: `field "password" is not sortable`},
+		{name: "injection", query: "sort=" + url.QueryEscape("name;DROP TABLE users"), code: ErrInvalidArgument, message: `field "name;DROP TABLE users" is not sortable`},
+		{name: "bad direction", query: "sort=name:up", code: ErrInvalidArgument, message: `sort direction "up" must be asc or desc`},
+		{name: "mixed direction", query: "sort=-name:asc", code: ErrInvalidArgument, message: `sort term "-name:asc" mixes - and a direction`},
+		{name: "duplicate field", query: "sort=name,-name", code: ErrInvalidArgument, message: `field "name" is sorted twice`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParsePageRequest(newQueryContext(tc.query), rules)
+			if tc.code != OK {
+				assert.ErrorIs(t, err, NewException().WithCode(tc.code))
+				assert.Equal(t, tc.message, err.(*Exception).Desc())
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+
+	t.Run("clamp", func(t *testing.T) {
+		clamped := rules
+		clamped.Clamp = true
+		for query, want := range map[string]PageRequest{
+			"offset=-1&limit=-5":   {Offset: 0, Limit: 10},
+			"offset=1001&limit=51": {Offset: 1000, Limit: 50},
+		} {
+			got, err := ParsePageRequest(newQueryContext(query+"&sort=id"), clamped)
+			assert.NoError(t, err, query)
+			want.Sort = []SortOrder{{Field: "id", Column: "o.id"}}
+			assert.Equal(t, want, got, query)
+		}
+
+		_, err := ParsePageRequest(newQueryContext("sort=password"), clamped)
+		assert.ErrorIs(t, err, NewInvalidArgumentError(), "sort stays strict")
+	})
+
+	t.Run("missing default limit", func(t *testing.T) {
+		got, err := ParsePageRequest(newQueryContext(""), PageRules{Limits: PageLimits{Max: 15}})
+		assert.NoError(t, err)
+		assert.Equal(t, 15, got.Limit)
+	})
+}
+
+func TestPageRequest_Clauses(t *testing.T) {
+	req := PageRequest{Offset: 40, Limit: 20, Sort: []SortOrder{
+		{Field: "created_at", Column: "created_at", Desc: true},
+		{Field: "id", Column: "id"},
+	}}
+	assert.Equal(t, "ORDER BY created_at DESC, id ASC", req.OrderBy())
+	assert.Equal(t, "LIMIT 20 OFFSET 40", req.LimitOffset())
+	assert.Equal(t, "ORDER BY created_at DESC, id ASC LIMIT 20 OFFSET 40", req.Suffix())
+
+	unsorted := PageRequest{Limit: 20}
+	assert.Equal(t, "", unsorted.OrderBy())
+	assert.Equal(t, "LIMIT 20 OFFSET 0", unsorted.Suffix())
+}
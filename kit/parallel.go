@@ -0,0 +1,123 @@
+package kit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrorPolicy decides how a Parallel group reacts to a failing step.
+type ErrorPolicy int
+
+const (
+	// FailFast cancels the context of the other steps at the first error, and returns that error.
+	FailFast ErrorPolicy = iota
+	// CollectAll lets every step run, and returns all the errors joined with errors.Join.
+	CollectAll
+)
+
+type namedStep struct {
+	name string
+	fn   StepFunc
+}
+
+// Parallel is a group of independent steps run concurrently, such as loading a user,
+// their permissions and their quota. Its Run method is a StepFunc, so a group can be
+// used as a single step of a Chain or a Saga.
+type Parallel struct {
+	limit  int
+	policy ErrorPolicy
+	steps  []namedStep
+}
+
+// NewParallel creates a group running at most limit steps at once, without limit when limit <= 0.
+func NewParallel(limit int, policy ErrorPolicy) *Parallel {
+	return &Parallel{limit: limit, policy: policy}
+}
+
+// Go adds a named step to the group, it runs when Run is called.
+func (p *Parallel) Go(name string, step StepFunc) *Parallel {
+	p.steps = append(p.steps, namedStep{name: name, fn: step})
+	return p
+}
+
+// Run runs the steps and waits for all of them. Failures are reported as *StepError values
+// whose index is the position of the step in the group. Steps that did not start before the
+// context was done fail like skipped Chain steps, and panics become ErrInternal Exceptions.
+func (p *Parallel) Run(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mu       sync.Mutex
+		errs     = make([]error, len(p.steps))
+		firstErr error
+		wg       sync.WaitGroup
+	)
+	fail := func(index int, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		errs[index] = &StepError{Name: p.steps[index].name, Index: index, Err: err}
+		if firstErr == nil {
+			firstErr = errs[index]
+			if p.policy == FailFast {
+				cancel()
+			}
+		}
+	}
+
+	var sem chan struct{}
+	if p.limit > 0 {
+		sem = make(chan struct{}, p.limit)
+	}
+
+	for i, step := range p.steps {
+		if err := ctx.Err(); err != nil {
+			fail(i, ContextError(err))
+			continue
+		}
+		if sem != nil {
+			select {
+			case sem <- struct{}{}:
+				// A slot frees up as a failing step cancels the context, select does not favor either case.
+				if err := ctx.Err(); err != nil {
+					<-sem
+					fail(i, ContextError(err))
+					continue
+				}
+			case <-ctx.Done():
+				fail(i, ContextError(ctx.Err()))
+				continue
+			}
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if sem != nil {
+				defer func() { <-sem }()
+			}
+			if err := runStep(ctx, step.fn); err != nil {
+				fail(i, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if p.policy == FailFast {
+		return firstErr
+	}
+	return errors.Join(errs...)
+}
+
+// runStep runs step, converting context errors like Chain does and recovering panics,
+// which would otherwise crash the process from a goroutine.
+func runStep(ctx context.Context, step StepFunc) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = NewInternalError().WithErr(fmt.Errorf("panic: %v", r))
+		}
+	}()
+	return ContextError(step(ctx))
+}
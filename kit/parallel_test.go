@@ -0,0 +1,148 @@
+package kit
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParallel(t *testing.T) {
+	t.Run("runs every step", func(t *testing.T) {
+		var user, permissions, quota string
+		err := NewParallel(0, FailFast).
+			Go("user", func(ctx context.Context) error { user = "alice"; return nil }).
+			Go("permissions", func(ctx context.Context) error { permissions = "admin"; return nil }).
+			Go("quota", func(ctx context.Context) error { quota = "10GB"; return nil }).
+			Run(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"alice", "admin", "10GB"}, []string{user, permissions, quota})
+	})
+
+	t.Run("steps run concurrently", func(t *testing.T) {
+		var wg sync.WaitGroup
+		wg.Add(3)
+		wait := func(ctx context.Context) error {
+			wg.Done()
+			wg.Wait() // deadlocks unless the three steps run at the same time
+			return nil
+		}
+
+		err := NewParallel(0, CollectAll).Go("a", wait).Go("b", wait).Go("c", wait).Run(context.Background())
+		assert.NoError(t, err)
+	})
+
+	t.Run("concurrency limit", func(t *testing.T) {
+		var running, peak atomic.Int32
+		step := func(ctx context.Context) error {
+			n := running.Add(1)
+			for {
+				old := peak.Load()
+				if n <= old || peak.CompareAndSwap(old, n) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			running.Add(-1)
+			return nil
+		}
+
+		p := NewParallel(2, CollectAll)
+		for range 6 {
+			p.Go("step", step)
+		}
+		assert.NoError(t, p.Run(context.Background()))
+		assert.Equal(t, int32(2), peak.Load())
+	})
+
+	t.Run("fail fast cancels the other steps", func(t *testing.T) {
+		failure := NewNotFoundError()
+		var canceled, skipped atomic.Bool
+
+		err := NewParallel(2, FailFast).
+			Go("slow", func(ctx context.Context) error {
+				<-ctx.Done()
+				canceled.Store(true)
+				return ctx.Err()
+			}).
+			Go("failing", func(ctx context.Context) error { return failure }).
+			Go("pending", func(ctx context.Context) error { skipped.Store(true); return nil }).
+			Run(context.Background())
+
+		var stepErr *StepError
+		assert.True(t, errors.As(err, &stepErr))
+		assert.Equal(t, "failing", stepErr.Name)
+		assert.Equal(t, 1, stepErr.Index)
+		assert.ErrorIs(t, err, failure)
+		assert.True(t, canceled.Load())
+		assert.False(t, skipped.Load())
+	})
+
+	t.Run("collect all", func(t *testing.T) {
+		permissionsErr := NewPermissionDeniedError()
+		quotaErr := errors.New("quota service down")
+		var userLoaded atomic.Bool
+
+		err := NewParallel(1, CollectAll).
+			Go("permissions", func(ctx context.Context) error { return permissionsErr }).
+			Go("user", func(ctx context.Context) error { userLoaded.Store(true); return nil }).
+			Go("quota", func(ctx context.Context) error { return quotaErr }).
+			Run(context.Background())
+
+		assert.True(t, userLoaded.Load())
+		assert.ErrorIs(t, err, permissionsErr)
+		assert.ErrorIs(t, err, quotaErr)
+		assert.Equal(t, "step 0 (permissions): Insufficient permissions\nstep 2 (quota): quota service down", err.Error())
+	})
+
+	t.Run("canceled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		executed := false
+
+		err := NewParallel(0, CollectAll).
+			Go("user", func(ctx context.Context) error { executed = true; return nil }).
+			Run(ctx)
+
+		assert.ErrorIs(t, err, NewCanceledError())
+		assert.False(t, executed)
+	})
+
+	t.Run("panic", func(t *testing.T) {
+		err := NewParallel(0, FailFast).
+			Go("broken", func(ctx context.Context) error { panic("boom") }).
+			Run(context.Background())
+
+		assert.ErrorIs(t, err, NewInternalError())
+		assert.Equal(t, "step 0 (broken): panic: boom", err.Error())
+	})
+
+	t.Run("group as a chain step", func(t *testing.T) {
+		var order []string
+		var mu sync.Mutex
+		lookup := func(name string) StepFunc {
+			return func(ctx context.Context) error {
+				mu.Lock()
+				defer mu.Unlock()
+				order = append(order, name)
+				return nil
+			}
+		}
+
+		c := NewChain(context.Background()).
+			Then("authenticate", lookup("authenticate")).
+			Then("lookups", NewParallel(0, FailFast).Go("user", lookup("user")).Go("quota", lookup("quota")).Run).
+			Then("render", lookup("render"))
+
+		assert.NoError(t, c.Error())
+		assert.Equal(t, "authenticate", order[0])
+		assert.ElementsMatch(t, []string{"user", "quota"}, order[1:3])
+		assert.Equal(t, "render", order[3])
+		assert.Len(t, c.Timings(), 3)
+	})
+}
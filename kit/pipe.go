@@ -0,0 +1,64 @@
+package kit
+
+// Pipeline is the typed variant of Alice: each stage receives the output of the previous one
+// instead of sharing captured variables. Stages are added with Pipe, which can change the
+// type of the value, and the pipeline stops at the first error like Alice.
+type Pipeline[T any] struct {
+	value T
+	err   error
+}
+
+// NewPipeline creates a pipeline starting with value.
+func NewPipeline[T any](value T) *Pipeline[T] {
+	return &Pipeline[T]{value: value}
+}
+
+// FromAlice starts a pipeline with the value returned by first, once the Alice chain succeeded.
+// If the chain failed, first is skipped and the pipeline carries the error of the chain.
+func FromAlice[T any](a *Alice, first func() (T, error)) *Pipeline[T] {
+	p := &Pipeline[T]{err: a.Error()}
+	if p.err == nil {
+		p.value, p.err = first()
+	}
+	return p
+}
+
+// Pipe runs stage with the value of p and returns a pipeline carrying its output.
+// If a previous stage failed, stage is skipped and the error is carried over.
+func Pipe[A, B any](p *Pipeline[A], stage func(A) (B, error)) *Pipeline[B] {
+	if p.err != nil {
+		return &Pipeline[B]{err: p.err}
+	}
+	value, err := stage(p.value)
+	return &Pipeline[B]{value: value, err: err}
+}
+
+// Then runs a stage that only inspects the value, such as a validation or a log line,
+// and keeps the value for the next stage. It is skipped if a previous stage failed.
+func (p *Pipeline[T]) Then(next func(T) error) *Pipeline[T] {
+	if p.err != nil {
+		return p
+	}
+	p.err = next(p.value)
+	return p
+}
+
+// Result returns the output of the last stage, or the zero value and the first error encountered.
+func (p *Pipeline[T]) Result() (T, error) {
+	if p.err != nil {
+		var zero T
+		return zero, p.err
+	}
+	return p.value, nil
+}
+
+// Error returns the first error encountered in the pipeline, or nil if all stages succeeded.
+func (p *Pipeline[T]) Error() error {
+	return p.err
+}
+
+// Alice returns an Alice chain carrying the error of the pipeline,
+// so that untyped steps can follow the typed stages.
+func (p *Pipeline[T]) Alice() *Alice {
+	return &Alice{err: p.err}
+}
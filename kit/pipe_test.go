@@ -0,0 +1,110 @@
+package kit
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPipeline(t *testing.T) {
+	t.Run("stages receive the previous output", func(t *testing.T) {
+		p := NewPipeline(" 42 ")
+		trimmed := Pipe(p, func(s string) (string, error) { return strings.TrimSpace(s), nil })
+		parsed := Pipe(trimmed, strconv.Atoi)
+		doubled := Pipe(parsed, func(n int) (int, error) { return n * 2, nil })
+
+		value, err := doubled.Result()
+		assert.NoError(t, err)
+		assert.Equal(t, 84, value)
+	})
+
+	t.Run("first error stops execution", func(t *testing.T) {
+		executed := false
+		parsed := Pipe(NewPipeline("not a number"), strconv.Atoi)
+		doubled := Pipe(parsed, func(n int) (int, error) { executed = true; return n * 2, nil })
+
+		value, err := doubled.Result()
+		assert.Error(t, err)
+		assert.ErrorIs(t, err, strconv.ErrSyntax)
+		assert.Equal(t, 0, value)
+		assert.False(t, executed)
+	})
+
+	t.Run("failed stage value is dropped", func(t *testing.T) {
+		failure := NewNotFoundError()
+		p := Pipe(NewPipeline(1), func(n int) (string, error) { return "partial", failure })
+
+		value, err := p.Result()
+		assert.Same(t, failure, err)
+		assert.Empty(t, value)
+	})
+
+	t.Run("then keeps the value", func(t *testing.T) {
+		var seen []int
+		p := NewPipeline(3).
+			Then(func(n int) error { seen = append(seen, n); return nil }).
+			Then(func(n int) error { seen = append(seen, n); return nil })
+
+		value, err := p.Result()
+		assert.NoError(t, err)
+		assert.Equal(t, 3, value)
+		assert.Equal(t, []int{3, 3}, seen)
+	})
+
+	t.Run("then stops execution", func(t *testing.T) {
+		invalid := NewInvalidArgumentError()
+		executed := false
+		p := NewPipeline(-1).
+			Then(func(n int) error {
+				if n < 0 {
+					return invalid
+				}
+				return nil
+			}).
+			Then(func(n int) error { executed = true; return nil })
+		squared := Pipe(p, func(n int) (int, error) { executed = true; return n * n, nil })
+
+		assert.Same(t, invalid, squared.Error())
+		assert.False(t, executed)
+	})
+
+	t.Run("from alice", func(t *testing.T) {
+		connected := false
+		a := NewAlice().Then(func() error { connected = true; return nil })
+		p := Pipe(FromAlice(a, func() (string, error) { return "7", nil }), strconv.Atoi)
+
+		value, err := p.Result()
+		assert.NoError(t, err)
+		assert.True(t, connected)
+		assert.Equal(t, 7, value)
+	})
+
+	t.Run("from failed alice", func(t *testing.T) {
+		expectedError := errors.New("connect failed")
+		executed := false
+		a := New(func() error { return expectedError })
+		p := FromAlice(a, func() (string, error) { executed = true; return "7", nil })
+
+		_, err := p.Result()
+		assert.Equal(t, expectedError, err)
+		assert.False(t, executed)
+	})
+
+	t.Run("back to alice", func(t *testing.T) {
+		var saved int
+		p := Pipe(NewPipeline("5"), strconv.Atoi)
+		value, _ := p.Result()
+		err := p.Alice().Then(func() error { saved = value; return nil }).Error()
+		assert.NoError(t, err)
+		assert.Equal(t, 5, saved)
+
+		executed := false
+		failed := Pipe(NewPipeline("x"), strconv.Atoi)
+		err = failed.Alice().Then(func() error { executed = true; return nil }).Error()
+		assert.ErrorIs(t, err, strconv.ErrSyntax)
+		assert.False(t, executed)
+	})
+}
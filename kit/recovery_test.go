@@ -0,0 +1,101 @@
+package kit
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestTranslator_RecoversPanic(t *testing.T) {
+	core, recorded := observer.New(zapcore.ErrorLevel)
+	translator := NewTranslator(WithLogger(zap.New(core).Sugar()))
+
+	w := serveTranslator(translator, func(ctx *gin.Context) (any, error) {
+		panic("something broke")
+	})
+
+	respBody := RespBody{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &respBody))
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.False(t, respBody.Succeeded)
+	assert.Equal(t, ErrInternal, respBody.Code)
+	assert.Equal(t, Messages[ErrInternal], respBody.Info)
+	assert.Equal(t, "panic: something broke", respBody.Desc) // tests run in debug mode
+
+	assert.Equal(t, 1, recorded.Len())
+	fields := recorded.All()[0].ContextMap()
+	assert.Equal(t, http.MethodGet, fields["method"])
+	assert.Equal(t, "/test", fields["path"])
+	assert.Equal(t, "something broke", fields["panic"])
+	assert.Contains(t, fields["stack"], "runtime/debug.Stack")
+}
+
+func TestTranslator_PanicDescHiddenInRelease(t *testing.T) {
+	translator := NewTranslator(
+		WithLogger(zap.NewNop().Sugar()),
+		WithDebugDetail(func(ctx *gin.Context) bool { return false }),
+	)
+
+	w := serveTranslator(translator, func(ctx *gin.Context) (any, error) {
+		panic("secret")
+	})
+
+	respBody := RespBody{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &respBody))
+	assert.Equal(t, ErrInternal, respBody.Code)
+	assert.Equal(t, "", respBody.Desc)
+}
+
+func TestRecovery(t *testing.T) {
+	r := gin.New()
+	r.Use(NewTranslator(WithLogger(zap.NewNop().Sugar())).Recovery())
+	r.GET("/gin", func(ctx *gin.Context) {
+		panic("plain gin handler")
+	})
+	r.GET("/written", func(ctx *gin.Context) {
+		ctx.String(http.StatusAccepted, "partial")
+		panic("after write")
+	})
+	r.GET("/abort", func(ctx *gin.Context) {
+		panic(http.ErrAbortHandler)
+	})
+
+	t.Run("plain gin handler", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/gin", http.NoBody)
+		r.ServeHTTP(w, req)
+
+		respBody := RespBody{}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &respBody))
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Equal(t, ErrInternal, respBody.Code)
+	})
+
+	t.Run("response already written", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/written", http.NoBody)
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusAccepted, w.Code)
+		assert.Equal(t, "partial", w.Body.String())
+	})
+
+	t.Run("abort handler is re-panicked", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/abort", http.NoBody)
+		assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+			r.ServeHTTP(w, req)
+		})
+	})
+
+	t.Run("default translator", func(t *testing.T) {
+		assert.NotNil(t, Recovery())
+	})
+}
@@ -0,0 +1,139 @@
+package kit
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// CategoryCommon is the category of the codes defined in code.go.
+const CategoryCommon = "common"
+
+// ErrCodeConflict is returned when a code is registered twice.
+var ErrCodeConflict = errors.New("business code already registered")
+
+// CodeInfo describes a registered business code.
+type CodeInfo struct {
+	Code       int    `json:"code"`        // Business code
+	Message    string `json:"message"`     // Default message, in the default locale of the catalog
+	HTTPStatus int    `json:"http_status"` // HTTP status, derived from the code when zero
+	Category   string `json:"category"`    // Owning module or domain, such as "coupon"
+}
+
+// CodeRegistry keeps track of business codes so that modules cannot silently clash.
+type CodeRegistry struct {
+	mu    sync.RWMutex
+	codes map[int]CodeInfo
+}
+
+// DefaultCodeRegistry holds the codes of code.go and the codes registered through RegisterCode.
+var DefaultCodeRegistry = newDefaultCodeRegistry()
+
+// NewCodeRegistry creates an empty registry.
+func NewCodeRegistry() *CodeRegistry {
+	return &CodeRegistry{
+		codes: map[int]CodeInfo{},
+	}
+}
+
+// Register adds a code to the registry.
+// It returns an error wrapping ErrCodeConflict when the code is already registered.
+func (r *CodeRegistry) Register(info CodeInfo) error {
+	if info.HTTPStatus == 0 {
+		info.HTTPStatus = HTTPStatus(info.Code)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if existing, ok := r.codes[info.Code]; ok {
+		return fmt.Errorf("%w: code %d is owned by category %q", ErrCodeConflict, info.Code, existing.Category)
+	}
+	r.codes[info.Code] = info
+	return nil
+}
+
+// MustRegister is like Register but panics on conflicts.
+func (r *CodeRegistry) MustRegister(info CodeInfo) {
+	if err := r.Register(info); err != nil {
+		panic(err)
+	}
+}
+
+// Lookup returns the registered information of code.
+func (r *CodeRegistry) Lookup(code int) (CodeInfo, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	info, ok := r.codes[code]
+	return info, ok
+}
+
+// List returns all registered codes ordered by code.
+func (r *CodeRegistry) List() []CodeInfo {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	list := make([]CodeInfo, 0, len(r.codes))
+	for _, info := range r.codes {
+		list = append(list, info)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Code < list[j].Code
+	})
+	return list
+}
+
+// ExportJSON returns the registered codes as an indented JSON array.
+func (r *CodeRegistry) ExportJSON() ([]byte, error) {
+	return json.MarshalIndent(r.List(), "", "  ")
+}
+
+// ExportMarkdown returns the registered codes as a Markdown table.
+func (r *CodeRegistry) ExportMarkdown() string {
+	var b strings.Builder
+	b.WriteString("| Code | HTTP | Category | Message |\n")
+	b.WriteString("|------|------|----------|---------|\n")
+	for _, info := range r.List() {
+		fmt.Fprintf(&b, "| %d | %d | %s | %s |\n",
+			info.Code, info.HTTPStatus, escapeMarkdownCell(info.Category), escapeMarkdownCell(info.Message))
+	}
+	return b.String()
+}
+
+// RegisterCode registers a code in DefaultCodeRegistry.
+// Its message becomes the default locale message of DefaultCatalog,
+// and its HTTP status is used by StatusMapping.
+func RegisterCode(info CodeInfo) error {
+	if err := DefaultCodeRegistry.Register(info); err != nil {
+		return err
+	}
+	if info.Message != "" {
+		DefaultCatalog.Register(DefaultCatalog.defaultLocale, map[int]string{info.Code: info.Message})
+	}
+	return nil
+}
+
+// MustRegisterCode is like RegisterCode but panics on conflicts.
+// It returns the code so it can be used in package level declarations:
+//
+//	var ErrCouponExpired = kit.MustRegisterCode(kit.CodeInfo{Code: 40010, Message: "Coupon expired", Category: "coupon"})
+func MustRegisterCode(info CodeInfo) int {
+	if err := RegisterCode(info); err != nil {
+		panic(err)
+	}
+	return info.Code
+}
+
+func escapeMarkdownCell(s string) string {
+	return strings.ReplaceAll(s, "|", `\|`)
+}
+
+func newDefaultCodeRegistry() *CodeRegistry {
+	registry := NewCodeRegistry()
+	for code, message := range Messages {
+		registry.MustRegister(CodeInfo{Code: code, Message: message, Category: CategoryCommon})
+	}
+	return registry
+}
@@ -0,0 +1,103 @@
+package kit
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCodeRegistry(t *testing.T) {
+	t.Run("register and lookup", func(t *testing.T) {
+		registry := NewCodeRegistry()
+		assert.NoError(t, registry.Register(CodeInfo{Code: 40010, Message: "Coupon expired", Category: "coupon"}))
+
+		info, ok := registry.Lookup(40010)
+		assert.True(t, ok)
+		assert.Equal(t, CodeInfo{Code: 40010, Message: "Coupon expired", HTTPStatus: http.StatusBadRequest, Category: "coupon"}, info)
+
+		_, ok = registry.Lookup(40011)
+		assert.False(t, ok)
+	})
+
+	t.Run("duplicate code", func(t *testing.T) {
+		registry := NewCodeRegistry()
+		registry.MustRegister(CodeInfo{Code: 40010, Category: "coupon"})
+
+		err := registry.Register(CodeInfo{Code: 40010, Category: "order"})
+		assert.True(t, errors.Is(err, ErrCodeConflict))
+		assert.Contains(t, err.Error(), `"coupon"`)
+
+		assert.Panics(t, func() {
+			registry.MustRegister(CodeInfo{Code: 40010, Category: "order"})
+		})
+	})
+
+	t.Run("list is ordered by code", func(t *testing.T) {
+		registry := NewCodeRegistry()
+		registry.MustRegister(CodeInfo{Code: 50010, Category: "b"})
+		registry.MustRegister(CodeInfo{Code: 40010, Category: "a", HTTPStatus: http.StatusGone})
+
+		assert.Equal(t, []CodeInfo{
+			{Code: 40010, HTTPStatus: http.StatusGone, Category: "a"},
+			{Code: 50010, HTTPStatus: http.StatusInternalServerError, Category: "b"},
+		}, registry.List())
+	})
+
+	t.Run("export", func(t *testing.T) {
+		registry := NewCodeRegistry()
+		registry.MustRegister(CodeInfo{Code: 40010, Message: "Coupon expired | used", Category: "coupon"})
+
+		data, err := registry.ExportJSON()
+		assert.NoError(t, err)
+		var decoded []CodeInfo
+		assert.NoError(t, json.Unmarshal(data, &decoded))
+		assert.Equal(t, registry.List(), decoded)
+
+		assert.Equal(t, "| Code | HTTP | Category | Message |\n"+
+			"|------|------|----------|---------|\n"+
+			"| 40010 | 400 | coupon | Coupon expired \\| used |\n", registry.ExportMarkdown())
+	})
+}
+
+func TestDefaultCodeRegistry(t *testing.T) {
+	t.Run("contains builtin codes", func(t *testing.T) {
+		for code, message := range Messages {
+			info, ok := DefaultCodeRegistry.Lookup(code)
+			assert.True(t, ok)
+			assert.Equal(t, message, info.Message)
+			assert.Equal(t, CategoryCommon, info.Category)
+		}
+	})
+
+	t.Run("builtin codes cannot be registered again", func(t *testing.T) {
+		assert.True(t, errors.Is(RegisterCode(CodeInfo{Code: ErrNotFound}), ErrCodeConflict))
+	})
+
+	t.Run("registered code feeds catalog and status mapping", func(t *testing.T) {
+		code := MustRegisterCode(CodeInfo{Code: 40977, Message: "Order locked", HTTPStatus: http.StatusLocked, Category: "order"})
+		t.Cleanup(func() { unregisterCode(code) })
+
+		message, ok := DefaultCatalog.Message("en", code)
+		assert.True(t, ok)
+		assert.Equal(t, "Order locked", message)
+		assert.Equal(t, http.StatusLocked, (&StatusMapping{}).Status(code))
+
+		assert.Panics(t, func() {
+			MustRegisterCode(CodeInfo{Code: code})
+		})
+	})
+}
+
+// unregisterCode undoes RegisterCode, so that tests leave the defaults as they found them.
+func unregisterCode(code int) {
+	DefaultCodeRegistry.mu.Lock()
+	delete(DefaultCodeRegistry.codes, code)
+	DefaultCodeRegistry.mu.Unlock()
+
+	DefaultCatalog.mu.Lock()
+	delete(DefaultCatalog.messages[DefaultCatalog.defaultLocale], code)
+	DefaultCatalog.mu.Unlock()
+}
@@ -2,11 +2,12 @@ package kit
 
 // RespBody represents the standard response structure for all API endpoints.
 type RespBody struct {
-	Succeeded bool   `json:"succeeded"`      // Whether the operation was successful
-	RespData  any    `json:"resp_data"`      // Returned data
-	Code      int    `json:"code,omitempty"` // Business status code
-	Info      string `json:"info,omitempty"` // Business hints
-	Desc      string `json:"desc,omitempty"` // Exception hints, typically only appear in development mode
+	Succeeded bool    `json:"succeeded"`         // Whether the operation was successful
+	RespData  any     `json:"resp_data"`         // Returned data
+	Code      int     `json:"code,omitempty"`    // Business status code
+	Info      string  `json:"info,omitempty"`    // Business hints
+	Desc      string  `json:"desc,omitempty"`    // Exception hints, typically only appear in development mode
+	Details   Details `json:"details,omitempty"` // Structured error details, such as field violations
 } // @name RespBody
 
 // PageBody represents a paginated response structure.
@@ -17,6 +18,22 @@ type PageBody struct {
 	List   any   `json:"list"`   // Data list
 } // @name PageBody
 
+// PageBodyOf is a PageBody whose list type is known, so that typed handlers document their items.
+type PageBodyOf[T any] struct {
+	Offset int   `json:"offset"` // Offset
+	Limit  int   `json:"limit"`  // Limit on the number of items
+	Total  int64 `json:"total"`  // Total number of items
+	List   []T   `json:"list"`   // Data list
+}
+
+// CursorPage represents a cursor paginated response structure.
+type CursorPage struct {
+	Items      any    `json:"items"`                 // Data list
+	NextCursor string `json:"next_cursor,omitempty"` // Cursor of the following page, empty on the last page
+	PrevCursor string `json:"prev_cursor,omitempty"` // Cursor of the preceding page, empty on the first page
+	HasMore    bool   `json:"has_more"`              // Whether items follow this page
+} // @name CursorPage
+
 // RowAffectedBody represents the response structure for database operations
 // that return the number of affected rows.
 type RowAffectedBody struct {
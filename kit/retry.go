@@ -0,0 +1,139 @@
+package kit
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"time"
+)
+
+// DefaultMaxAttempts is the number of attempts of a RetryPolicy whose MaxAttempts is zero.
+const DefaultMaxAttempts = 3
+
+// Clock abstracts time so that retries can be tested without waiting.
+type Clock interface {
+	Now() time.Time                         // Current time
+	After(d time.Duration) <-chan time.Time // Channel receiving once d elapsed
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time                         { return time.Now() }
+func (systemClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// SystemClock is the Clock of the time package.
+var SystemClock Clock = systemClock{}
+
+// Backoff returns the delay before a retry, retry counting from 1.
+type Backoff func(retry int) time.Duration
+
+// FixedBackoff waits delay before every retry.
+func FixedBackoff(delay time.Duration) Backoff {
+	return func(int) time.Duration {
+		return delay
+	}
+}
+
+// ExponentialBackoff waits initial before the first retry and doubles the delay
+// before each following retry, up to maxDelay.
+func ExponentialBackoff(initial, maxDelay time.Duration) Backoff {
+	return func(retry int) time.Duration {
+		delay := initial
+		for i := 1; i < retry && delay < maxDelay; i++ {
+			delay *= 2
+		}
+		return min(delay, maxDelay)
+	}
+}
+
+// JitterBackoff waits a random delay between 0 and the delay of backoff,
+// which spreads the retries of clients that failed at the same time.
+func JitterBackoff(backoff Backoff) Backoff {
+	return func(retry int) time.Duration {
+		delay := backoff(retry)
+		if delay <= 0 {
+			return 0
+		}
+		return rand.N(delay + 1)
+	}
+}
+
+// DefaultRetryable reports whether err is an ErrUnavailable, ErrAborted or ErrResourceExhausted
+// business error, which are transient by definition.
+func DefaultRetryable(err error) bool {
+	var ex BusinessError
+	if !errors.As(err, &ex) {
+		return false
+	}
+	switch ex.Code() {
+	case ErrUnavailable, ErrAborted, ErrResourceExhausted:
+		return true
+	}
+	return false
+}
+
+// RetryPolicy retries functions failing with transient errors.
+// The zero value makes DefaultMaxAttempts attempts without waiting.
+type RetryPolicy struct {
+	MaxAttempts int                  // Attempts including the first one, DefaultMaxAttempts when zero
+	MaxElapsed  time.Duration        // No retry starts after MaxElapsed since the first attempt, unlimited when zero
+	Backoff     Backoff              // Delay before each retry, no delay when nil
+	Retryable   func(err error) bool // Whether err is worth a retry, DefaultRetryable when nil
+	Clock       Clock                // Clock measuring delays, SystemClock when nil
+}
+
+// Do calls fn until it succeeds, fails with an error that is not retryable, or the policy
+// gives up, and returns the last error. When ctx is done while waiting for a retry,
+// the ErrCanceled or ErrDeadlineExceeded Exception is joined to the last error.
+func (p RetryPolicy) Do(ctx context.Context, fn StepFunc) error {
+	clock := p.Clock
+	if clock == nil {
+		clock = SystemClock
+	}
+	retryable := p.Retryable
+	if retryable == nil {
+		retryable = DefaultRetryable
+	}
+	maxAttempts := p.MaxAttempts
+	if maxAttempts == 0 {
+		maxAttempts = DefaultMaxAttempts
+	}
+
+	start := clock.Now()
+	for attempt := 1; ; attempt++ {
+		err := fn(ctx)
+		if err == nil || !retryable(err) || attempt >= maxAttempts {
+			return err
+		}
+
+		var delay time.Duration
+		if p.Backoff != nil {
+			delay = p.Backoff(attempt)
+		}
+		if p.MaxElapsed > 0 && clock.Now().Add(delay).Sub(start) > p.MaxElapsed {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return errors.Join(ContextError(ctx.Err()), err)
+		case <-clock.After(delay):
+		}
+	}
+}
+
+// WrapStep returns step retried with the policy, to be used in a Chain, a Saga or a Parallel group.
+func (p RetryPolicy) WrapStep(step StepFunc) StepFunc {
+	return func(ctx context.Context) error {
+		return p.Do(ctx, step)
+	}
+}
+
+// Wrap returns fn retried with the policy, to be used in an Alice chain.
+func (p RetryPolicy) Wrap(fn func() error) func() error {
+	return func() error {
+		return p.Do(context.Background(), func(context.Context) error {
+			return fn()
+		})
+	}
+}
@@ -0,0 +1,193 @@
+package kit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeClock advances instantly and records the requested delays.
+type fakeClock struct {
+	now    time.Time
+	delays []time.Duration
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.delays = append(c.delays, d)
+	c.now = c.now.Add(d)
+	ch := make(chan time.Time, 1)
+	ch <- c.now
+	return ch
+}
+
+func TestBackoff(t *testing.T) {
+	t.Run("fixed", func(t *testing.T) {
+		b := FixedBackoff(time.Second)
+		assert.Equal(t, time.Second, b(1))
+		assert.Equal(t, time.Second, b(10))
+	})
+
+	t.Run("exponential", func(t *testing.T) {
+		b := ExponentialBackoff(100*time.Millisecond, time.Second)
+		var delays []time.Duration
+		for retry := 1; retry <= 6; retry++ {
+			delays = append(delays, b(retry))
+		}
+		assert.Equal(t, []time.Duration{
+			100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond,
+			800 * time.Millisecond, time.Second, time.Second,
+		}, delays)
+		assert.Equal(t, time.Second, b(1000))
+	})
+
+	t.Run("jitter", func(t *testing.T) {
+		b := JitterBackoff(FixedBackoff(time.Second))
+		for range 100 {
+			d := b(1)
+			assert.GreaterOrEqual(t, d, time.Duration(0))
+			assert.LessOrEqual(t, d, time.Second)
+		}
+		assert.Equal(t, time.Duration(0), JitterBackoff(FixedBackoff(0))(1))
+	})
+}
+
+func TestDefaultRetryable(t *testing.T) {
+	assert.True(t, DefaultRetryable(NewUnavailableError()))
+	assert.True(t, DefaultRetryable(NewAbortedError()))
+	assert.True(t, DefaultRetryable(NewResourceExhaustedError()))
+	assert.True(t, DefaultRetryable(&StepError{Name: "call", Err: NewUnavailableError()}))
+	assert.False(t, DefaultRetryable(NewNotFoundError()))
+	assert.False(t, DefaultRetryable(errors.New("plain error")))
+}
+
+func TestRetryPolicy(t *testing.T) {
+	failing := func(attempts *int, errs ...error) StepFunc {
+		return func(ctx context.Context) error {
+			*attempts++
+			if *attempts <= len(errs) {
+				return errs[*attempts-1]
+			}
+			return nil
+		}
+	}
+
+	t.Run("retries until success", func(t *testing.T) {
+		clock := &fakeClock{}
+		p := RetryPolicy{MaxAttempts: 5, Backoff: ExponentialBackoff(time.Second, time.Minute), Clock: clock}
+		attempts := 0
+
+		err := p.Do(context.Background(), failing(&attempts, NewUnavailableError(), NewAbortedError()))
+		assert.NoError(t, err)
+		assert.Equal(t, 3, attempts)
+		assert.Equal(t, []time.Duration{time.Second, 2 * time.Second}, clock.delays)
+	})
+
+	t.Run("max attempts", func(t *testing.T) {
+		clock := &fakeClock{}
+		p := RetryPolicy{Backoff: FixedBackoff(time.Second), Clock: clock}
+		attempts := 0
+		last := NewUnavailableError().WithDesc("third")
+
+		err := p.Do(context.Background(), failing(&attempts, NewUnavailableError(), NewUnavailableError(), last, nil))
+		assert.Same(t, last, err)
+		assert.Equal(t, DefaultMaxAttempts, attempts)
+		assert.Len(t, clock.delays, DefaultMaxAttempts-1)
+	})
+
+	t.Run("not retryable", func(t *testing.T) {
+		clock := &fakeClock{}
+		attempts := 0
+		failure := NewInvalidArgumentError()
+
+		err := RetryPolicy{Clock: clock}.Do(context.Background(), failing(&attempts, failure))
+		assert.Same(t, failure, err)
+		assert.Equal(t, 1, attempts)
+		assert.Empty(t, clock.delays)
+	})
+
+	t.Run("custom predicate", func(t *testing.T) {
+		attempts := 0
+		timeout := errors.New("i/o timeout")
+		p := RetryPolicy{Clock: &fakeClock{}, Retryable: func(err error) bool { return errors.Is(err, timeout) }}
+
+		assert.NoError(t, p.Do(context.Background(), failing(&attempts, timeout)))
+		assert.Equal(t, 2, attempts)
+	})
+
+	t.Run("max elapsed time", func(t *testing.T) {
+		clock := &fakeClock{}
+		p := RetryPolicy{
+			MaxAttempts: 10,
+			MaxElapsed:  5 * time.Second,
+			Backoff:     FixedBackoff(2 * time.Second),
+			Clock:       clock,
+		}
+		attempts := 0
+		errs := make([]error, 10)
+		for i := range errs {
+			errs[i] = NewUnavailableError()
+		}
+
+		err := p.Do(context.Background(), failing(&attempts, errs...))
+		assert.ErrorIs(t, err, NewUnavailableError())
+		assert.Equal(t, 3, attempts)
+		assert.Equal(t, []time.Duration{2 * time.Second, 2 * time.Second}, clock.delays)
+	})
+
+	t.Run("canceled while waiting", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		attempts := 0
+		failure := NewUnavailableError()
+		p := RetryPolicy{Backoff: FixedBackoff(time.Hour)}
+
+		err := p.Do(ctx, func(ctx context.Context) error {
+			attempts++
+			cancel()
+			return failure
+		})
+		assert.ErrorIs(t, err, NewCanceledError())
+		assert.ErrorIs(t, err, failure)
+		assert.Equal(t, 1, attempts)
+	})
+
+	t.Run("alice step", func(t *testing.T) {
+		attempts := 0
+		p := RetryPolicy{Clock: &fakeClock{}}
+		var loaded bool
+
+		a := NewAlice().
+			Then(p.Wrap(func() error {
+				attempts++
+				if attempts == 1 {
+					return NewResourceExhaustedError()
+				}
+				return nil
+			})).
+			Then(func() error { loaded = true; return nil })
+
+		assert.NoError(t, a.Error())
+		assert.Equal(t, 2, attempts)
+		assert.True(t, loaded)
+	})
+
+	t.Run("chain step", func(t *testing.T) {
+		attempts := 0
+		p := RetryPolicy{MaxAttempts: 2, Clock: &fakeClock{}}
+
+		c := NewChain(context.Background()).
+			Then("fetch", p.WrapStep(failing(&attempts, NewUnavailableError(), NewUnavailableError())))
+
+		var stepErr *StepError
+		assert.True(t, errors.As(c.Error(), &stepErr))
+		assert.Equal(t, "fetch", stepErr.Name)
+		assert.ErrorIs(t, c.Error(), NewUnavailableError())
+		assert.Equal(t, 2, attempts)
+	})
+}
@@ -0,0 +1,81 @@
+package kit
+
+import (
+	"path"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// RouteDoc documents a route, it is used to generate the OpenAPI document.
+// The request and response types come from the handler, see Handle.
+type RouteDoc struct {
+	OperationID string   // Unique name of the operation, derived from the method and path when empty
+	Summary     string   // Short summary of the route
+	Description string   // Longer description of the route
+	Tags        []string // Tags grouping the routes
+	Codes       []int    // Business codes the route may return
+}
+
+// RouteInfo describes a route registered through a RouterGroup.
+type RouteInfo struct {
+	Method   string       // HTTP method
+	Path     string       // Absolute path, in gin syntax such as /users/:id
+	Request  reflect.Type // Request type of a handler built by Handle, bound the way BindRequest binds it
+	Response reflect.Type // RespData type of a handler built by Handle
+	Doc      RouteDoc     // Documentation set through RouterGroup.Doc
+}
+
+type routeTable struct {
+	mu     sync.RWMutex
+	routes []RouteInfo
+}
+
+func (t *routeTable) add(route RouteInfo) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.routes = append(t.routes, route)
+}
+
+func (t *routeTable) list() []RouteInfo {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return append([]RouteInfo(nil), t.routes...)
+}
+
+// Doc returns a RouterGroup that attaches doc to the next route it registers:
+//
+//	api.Doc(kit.RouteDoc{Summary: "Get a user", Codes: []int{kit.ErrNotFound}}).
+//		GET("/users/:id", kit.Handle(getUser))
+func (r *RouterGroup) Doc(doc RouteDoc) *RouterGroup {
+	c := *r
+	c.doc = &doc
+	return &c
+}
+
+// Routes returns the routes registered through the group, its parent and its nested groups,
+// in registration order.
+func (r *RouterGroup) Routes() []RouteInfo {
+	return r.routes.list()
+}
+
+// takeDoc returns the pending documentation, so it only applies to one route.
+func (r *RouterGroup) takeDoc() RouteDoc {
+	if r.doc == nil {
+		return RouteDoc{}
+	}
+	doc := *r.doc
+	r.doc = nil
+	return doc
+}
+
+func joinPaths(absolutePath, relativePath string) string {
+	if relativePath == "" {
+		return absolutePath
+	}
+	finalPath := path.Join(absolutePath, relativePath)
+	if strings.HasSuffix(relativePath, "/") && !strings.HasSuffix(finalPath, "/") {
+		return finalPath + "/"
+	}
+	return finalPath
+}
@@ -0,0 +1,90 @@
+package kit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// CompensationError reports a compensation that failed while undoing a saga.
+type CompensationError struct {
+	Name  string // Name of the compensated step
+	Index int    // Position of the compensated step in the saga
+	Err   error  // Error returned by the compensation
+}
+
+// Error implements the error interface.
+func (e *CompensationError) Error() string {
+	if e.Name == "" {
+		return fmt.Sprintf("compensate step %d: %v", e.Index, e.Err)
+	}
+	return fmt.Sprintf("compensate step %d (%s): %v", e.Index, e.Name, e.Err)
+}
+
+// Unwrap returns the error of the compensation.
+func (e *CompensationError) Unwrap() error {
+	return e.Err
+}
+
+type compensation struct {
+	name  string
+	index int
+	fn    StepFunc
+}
+
+// Saga is a Chain whose steps can register a compensation undoing them.
+// When a step fails, the compensations of the completed steps run in reverse order,
+// even when the context was canceled, since undoing is what a canceled saga needs.
+type Saga struct {
+	chain         *Chain
+	compensations []compensation
+	err           error
+}
+
+// NewSaga creates a saga whose steps run with ctx.
+func NewSaga(ctx context.Context) *Saga {
+	return &Saga{chain: NewChain(ctx)}
+}
+
+// Then runs a named step like Chain.Then. Once the step succeeds, compensate is
+// registered to undo it if a later step fails. A nil compensate registers nothing.
+func (s *Saga) Then(name string, step, compensate StepFunc) *Saga {
+	index := s.chain.index
+	if s.chain.Then(name, step).Error() != nil {
+		if s.err == nil {
+			s.err = s.compensate()
+		}
+		return s
+	}
+	if compensate != nil {
+		s.compensations = append(s.compensations, compensation{name: name, index: index, fn: compensate})
+	}
+	return s
+}
+
+// Error returns nil if all steps succeeded. Otherwise it returns the *StepError of the failed step,
+// joined through errors.Join with a *CompensationError for each compensation that failed.
+func (s *Saga) Error() error {
+	return s.err
+}
+
+// Timings returns the timing of the executed steps, compensations excluded.
+func (s *Saga) Timings() []StepTiming {
+	return s.chain.Timings()
+}
+
+func (s *Saga) compensate() error {
+	ctx := context.WithoutCancel(s.chain.ctx)
+	errs := []error{s.chain.Error()}
+	for i := len(s.compensations) - 1; i >= 0; i-- {
+		c := s.compensations[i]
+		if err := c.fn(ctx); err != nil {
+			errs = append(errs, &CompensationError{Name: c.name, Index: c.index, Err: err})
+		}
+	}
+	s.compensations = nil
+	if len(errs) == 1 {
+		return errs[0]
+	}
+	return errors.Join(errs...)
+}
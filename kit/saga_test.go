@@ -0,0 +1,96 @@
+package kit
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSaga(t *testing.T) {
+	record := func(log *[]string, entry string, err error) StepFunc {
+		return func(ctx context.Context) error {
+			*log = append(*log, entry)
+			return err
+		}
+	}
+
+	t.Run("success keeps every step", func(t *testing.T) {
+		var log []string
+		s := NewSaga(context.Background()).
+			Then("reserve stock", record(&log, "reserve", nil), record(&log, "release", nil)).
+			Then("charge card", record(&log, "charge", nil), record(&log, "refund", nil))
+
+		assert.NoError(t, s.Error())
+		assert.Equal(t, []string{"reserve", "charge"}, log)
+		assert.Len(t, s.Timings(), 2)
+	})
+
+	t.Run("failure compensates in reverse order", func(t *testing.T) {
+		var log []string
+		failure := NewUnavailableError()
+
+		s := NewSaga(context.Background()).
+			Then("reserve stock", record(&log, "reserve", nil), record(&log, "release", nil)).
+			Then("notify", record(&log, "notify", nil), nil).
+			Then("charge card", record(&log, "charge", nil), record(&log, "refund", nil)).
+			Then("create shipment", record(&log, "ship", failure), record(&log, "cancel shipment", nil)).
+			Then("send mail", record(&log, "mail", nil), nil)
+
+		assert.Equal(t, []string{"reserve", "notify", "charge", "ship", "refund", "release"}, log)
+		assert.ErrorIs(t, s.Error(), failure)
+
+		var stepErr *StepError
+		assert.True(t, errors.As(s.Error(), &stepErr))
+		assert.Equal(t, "create shipment", stepErr.Name)
+		assert.Equal(t, 3, stepErr.Index)
+	})
+
+	t.Run("compensation errors are joined", func(t *testing.T) {
+		failure := errors.New("carrier down")
+		refundErr := errors.New("gateway timeout")
+		var log []string
+
+		err := NewSaga(context.Background()).
+			Then("reserve stock", record(&log, "reserve", nil), record(&log, "release", nil)).
+			Then("charge card", record(&log, "charge", nil), record(&log, "refund", refundErr)).
+			Then("create shipment", record(&log, "ship", failure), nil).
+			Error()
+
+		assert.Equal(t, []string{"reserve", "charge", "ship", "refund", "release"}, log)
+		assert.ErrorIs(t, err, failure)
+		assert.ErrorIs(t, err, refundErr)
+
+		var compensationErr *CompensationError
+		assert.True(t, errors.As(err, &compensationErr))
+		assert.Equal(t, "charge card", compensationErr.Name)
+		assert.Equal(t, 1, compensationErr.Index)
+		assert.Equal(t, "step 2 (create shipment): carrier down\ncompensate step 1 (charge card): gateway timeout", err.Error())
+	})
+
+	t.Run("compensations run after cancellation", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		var log []string
+
+		err := NewSaga(ctx).
+			Then("reserve stock", func(ctx context.Context) error {
+				cancel()
+				return nil
+			}, func(ctx context.Context) error {
+				assert.NoError(t, ctx.Err())
+				log = append(log, "release")
+				return nil
+			}).
+			Then("charge card", record(&log, "charge", nil), nil).
+			Error()
+
+		assert.ErrorIs(t, err, NewCanceledError())
+		assert.Equal(t, []string{"release"}, log)
+	})
+
+	t.Run("unnamed compensation error", func(t *testing.T) {
+		err := &CompensationError{Index: 2, Err: errors.New("boom")}
+		assert.Equal(t, "compensate step 2: boom", err.Error())
+	})
+}
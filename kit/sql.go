@@ -1,13 +1,299 @@
 package kit
 
-// WrapLike wraps the search value with SQL LIKE wildcards on both sides.
+import (
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Dialect is the SQL flavor of the generated fragments.
+type Dialect int
+
+const (
+	DialectMySQL    Dialect = iota // MySQL and MariaDB
+	DialectPostgres                // PostgreSQL, which uses ILIKE for case-insensitive matching
+	DialectSQLite                  // SQLite
+)
+
+// Placeholder returns the placeholder of the nth argument, counting from 1: $n for PostgreSQL and ? otherwise.
+func (d Dialect) Placeholder(n int) string {
+	if d == DialectPostgres {
+		return "$" + strconv.Itoa(n)
+	}
+	return "?"
+}
+
+// LikeEscapeChar is the escape character used by EscapeLike and the Wrap helpers.
+// MySQL and PostgreSQL use it by default, SQLite needs an ESCAPE clause, see Like.Condition.
+const LikeEscapeChar = '\\'
+
+// Like builds LIKE patterns whose user value only matches literally.
+type Like struct {
+	Dialect    Dialect // SQL flavor of Condition
+	EscapeChar rune    // Escape character, LikeEscapeChar when zero
+	MaxLen     int     // Searched values are truncated to MaxLen runes when positive, bounding the pattern cost
+}
+
+// EscapeLike escapes the %, _ and backslash characters of value, so that it matches literally in a LIKE pattern.
+// Example: EscapeLike("50%") returns "50\%"
+func EscapeLike(value string) string {
+	return Like{}.Escape(value)
+}
+
+// WrapLike wraps the escaped search value with SQL LIKE wildcards on both sides.
 // Example: WrapLike("test") returns "%test%"
 func WrapLike(searchValue string) string {
-	return "%" + searchValue + "%"
+	return Like{}.Wrap(searchValue)
 }
 
-// WrapLeftLike wraps the search value with SQL LIKE wildcard on the left side.
+// WrapLeftLike wraps the escaped search value with SQL LIKE wildcard on the left side.
 // Example: WrapLeftLike("test") returns "%test"
 func WrapLeftLike(searchValue string) string {
-	return "%" + searchValue
+	return Like{}.WrapLeft(searchValue)
+}
+
+// WrapRightLike wraps the escaped search value with SQL LIKE wildcard on the right side.
+// Prefix searches can use an index, unlike the other patterns.
+// Example: WrapRightLike("test") returns "test%"
+func WrapRightLike(searchValue string) string {
+	return Like{}.WrapRight(searchValue)
+}
+
+// Escape truncates value to MaxLen and escapes its wildcards and escape characters.
+func (l Like) Escape(value string) string {
+	if l.MaxLen > 0 {
+		if runes := []rune(value); len(runes) > l.MaxLen {
+			value = string(runes[:l.MaxLen])
+		}
+	}
+
+	escape := l.escapeChar()
+	var b strings.Builder
+	b.Grow(len(value))
+	for _, r := range value {
+		if r == '%' || r == '_' || r == escape {
+			b.WriteRune(escape)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// Wrap returns the pattern matching values that contain value.
+func (l Like) Wrap(value string) string {
+	return "%" + l.Escape(value) + "%"
+}
+
+// WrapLeft returns the pattern matching values that end with value.
+func (l Like) WrapLeft(value string) string {
+	return "%" + l.Escape(value)
+}
+
+// WrapRight returns the pattern matching values that start with value.
+func (l Like) WrapRight(value string) string {
+	return l.Escape(value) + "%"
+}
+
+// Operator returns ILIKE for PostgreSQL and LIKE otherwise,
+// matching case-insensitively like the default MySQL collations and SQLite.
+func (l Like) Operator() string {
+	if l.Dialect == DialectPostgres {
+		return "ILIKE"
+	}
+	return "LIKE"
+}
+
+// EscapeClause returns the ESCAPE clause declaring the escape character, quoted for the dialect.
+func (l Like) EscapeClause() string {
+	escape := string(l.escapeChar())
+	switch escape {
+	case `\`:
+		// MySQL string literals treat the backslash as an escape character themselves.
+		if l.Dialect == DialectMySQL {
+			escape = `\\`
+		}
+	case "'":
+		escape = "''"
+	}
+	return "ESCAPE '" + escape + "'"
+}
+
+// Condition returns the condition matching column against the pattern bound to placeholder,
+// such as "name ILIKE $1 ESCAPE '\'". Column must be trusted, it is not quoted.
+func (l Like) Condition(column, placeholder string) string {
+	return column + " " + l.Operator() + " " + placeholder + " " + l.EscapeClause()
+}
+
+func (l Like) escapeChar() rune {
+	if l.EscapeChar == 0 {
+		return LikeEscapeChar
+	}
+	return l.EscapeChar
+}
+
+// Where builds a WHERE clause from optional filters, a condition is only added when its value is not zero,
+// so the filters of a list endpoint can be applied without checking each of them:
+//
+//	query, args := kit.NewWhere(kit.DialectPostgres).
+//		Eq("status", req.Status).
+//		Like("name", req.Name).
+//		PageQuery("SELECT * FROM users", page)
+//
+// Columns must be trusted, they are not quoted. Values are always bound as arguments.
+type Where struct {
+	dialect    Dialect
+	conditions []string // Conditions with the placeholders of the dialect
+	args       []any
+}
+
+// NewWhere creates an empty builder for dialect.
+func NewWhere(dialect Dialect) *Where {
+	return &Where{dialect: dialect}
+}
+
+// Eq adds column = value.
+func (w *Where) Eq(column string, value any) *Where {
+	if arg, ok := nonZero(value); ok {
+		w.add(column+" = ?", arg)
+	}
+	return w
+}
+
+// In adds column IN (values...) when values is a non-empty slice.
+func (w *Where) In(column string, values any) *Where {
+	v := reflect.ValueOf(values)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return w.Eq(column, values)
+	}
+	if v.Len() == 0 {
+		return w
+	}
+
+	args := make([]any, v.Len())
+	for i := range args {
+		args[i] = v.Index(i).Interface()
+	}
+	w.add(column+" IN ("+strings.Repeat("?, ", len(args)-1)+"?)", args...)
+	return w
+}
+
+// Like adds a case-insensitive match of the columns containing value, see Like.Wrap.
+func (w *Where) Like(column, value string) *Where {
+	if value != "" {
+		like := Like{Dialect: w.dialect}
+		w.add(like.Condition(column, "?"), like.Wrap(value))
+	}
+	return w
+}
+
+// LikePrefix adds a case-insensitive match of the columns starting with value, see Like.WrapRight.
+func (w *Where) LikePrefix(column, value string) *Where {
+	if value != "" {
+		like := Like{Dialect: w.dialect}
+		w.add(like.Condition(column, "?"), like.WrapRight(value))
+	}
+	return w
+}
+
+// Between adds column BETWEEN from AND to, or a single bound when the other one is zero.
+func (w *Where) Between(column string, from, to any) *Where {
+	fromArg, hasFrom := nonZero(from)
+	toArg, hasTo := nonZero(to)
+	switch {
+	case hasFrom && hasTo:
+		w.add(column+" BETWEEN ? AND ?", fromArg, toArg)
+	case hasFrom:
+		w.add(column+" >= ?", fromArg)
+	case hasTo:
+		w.add(column+" <= ?", toArg)
+	}
+	return w
+}
+
+// IsNull adds column IS NULL when null is true and column IS NOT NULL when it is false.
+func (w *Where) IsNull(column string, null *bool) *Where {
+	if null == nil {
+		return w
+	}
+	if *null {
+		w.add(column + " IS NULL")
+	} else {
+		w.add(column + " IS NOT NULL")
+	}
+	return w
+}
+
+// Raw adds a condition written with ? placeholders, which are numbered for the dialect.
+// Only the first len(args) ? are placeholders, a literal ? such as the jsonb ? operator
+// of PostgreSQL is kept as long as it follows them.
+func (w *Where) Raw(condition string, args ...any) *Where {
+	w.add("("+condition+")", args...)
+	return w
+}
+
+// Build returns the WHERE clause and its arguments, or an empty clause without conditions.
+func (w *Where) Build() (string, []any) {
+	if len(w.conditions) == 0 {
+		return "", nil
+	}
+	return "WHERE " + strings.Join(w.conditions, " AND "), append([]any(nil), w.args...)
+}
+
+// Query appends the WHERE clause to base, such as a SELECT COUNT(*) counting the filtered rows.
+func (w *Where) Query(base string) (string, []any) {
+	where, args := w.Build()
+	if where == "" {
+		return base, args
+	}
+	return base + " " + where, args
+}
+
+// PageQuery appends the WHERE clause and the ORDER BY, LIMIT and OFFSET clauses of page to base.
+// The limit and offset are bound as arguments.
+func (w *Where) PageQuery(base string, page PageRequest) (string, []any) {
+	query, args := w.Query(base)
+	if orderBy := page.OrderBy(); orderBy != "" {
+		query += " " + orderBy
+	}
+	n := len(args) + 1
+	query += " LIMIT " + w.dialect.Placeholder(n) + " OFFSET " + w.dialect.Placeholder(n+1)
+	return query, append(args, page.Limit, page.Offset)
+}
+
+func (w *Where) add(condition string, args ...any) {
+	w.conditions = append(w.conditions, w.number(condition, len(w.args)+1, len(args)))
+	w.args = append(w.args, args...)
+}
+
+// number replaces the first count ? placeholders of condition by the placeholders
+// of the dialect, counting from first.
+func (w *Where) number(condition string, first, count int) string {
+	if w.dialect != DialectPostgres || count == 0 {
+		return condition
+	}
+	var b strings.Builder
+	for n := first; n < first+count; n++ {
+		before, after, found := strings.Cut(condition, "?")
+		if !found {
+			break
+		}
+		b.WriteString(before)
+		b.WriteString(w.dialect.Placeholder(n))
+		condition = after
+	}
+	b.WriteString(condition)
+	return b.String()
+}
+
+// nonZero reports whether value is set, dereferencing pointers so that a pointer to zero
+// still filters, which lets optional query parameters match zero values explicitly.
+func nonZero(value any) (any, bool) {
+	v := reflect.ValueOf(value)
+	if !v.IsValid() || v.IsZero() {
+		return nil, false
+	}
+	if v.Kind() == reflect.Pointer {
+		return v.Elem().Interface(), true
+	}
+	return value, true
 }
@@ -16,3 +16,199 @@ func TestWrapLeftLike(t *testing.T) {
 	expected := "%test"
 	assert.Equal(t, expected, result)
 }
+
+func TestWrapRightLike(t *testing.T) {
+	result := WrapRightLike("test")
+	expected := "test%"
+	assert.Equal(t, expected, result)
+}
+
+func TestWrapLike_Escaping(t *testing.T) {
+	testCases := []struct {
+		name     string
+		wrap     func(string) string
+		value    string
+		expected string
+	}{
+		{name: "percent", wrap: WrapLike, value: "50%", expected: `%50\%%`},
+		{name: "underscore", wrap: WrapLeftLike, value: "a_b", expected: `%a\_b`},
+		{name: "backslash", wrap: WrapRightLike, value: `C:\dir`, expected: `C:\\dir%`},
+		{name: "escape only", wrap: EscapeLike, value: `%_\`, expected: `\%\_\\`},
+		{name: "unicode", wrap: WrapLike, value: "用户_1", expected: `%用户\_1%`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.wrap(tc.value))
+		})
+	}
+}
+
+func TestLike(t *testing.T) {
+	t.Run("custom escape character", func(t *testing.T) {
+		like := Like{EscapeChar: '!'}
+		assert.Equal(t, `%100!%!!\%`, like.Wrap(`100%!\`))
+		assert.Equal(t, "ESCAPE '!'", like.EscapeClause())
+	})
+
+	t.Run("quote escape character", func(t *testing.T) {
+		assert.Equal(t, "ESCAPE ''''", Like{EscapeChar: '\''}.EscapeClause())
+	})
+
+	t.Run("max length", func(t *testing.T) {
+		like := Like{MaxLen: 3}
+		assert.Equal(t, `ab\%%`, like.WrapRight("ab%cdef"))
+		assert.Equal(t, "%用户名%", like.Wrap("用户名称"))
+	})
+
+	t.Run("dialects", func(t *testing.T) {
+		testCases := []struct {
+			dialect  Dialect
+			expected string
+		}{
+			{dialect: DialectMySQL, expected: `name LIKE ? ESCAPE '\\'`},
+			{dialect: DialectPostgres, expected: `name ILIKE $1 ESCAPE '\'`},
+			{dialect: DialectSQLite, expected: `name LIKE ? ESCAPE '\'`},
+		}
+
+		for _, tc := range testCases {
+			placeholder := "?"
+			if tc.dialect == DialectPostgres {
+				placeholder = "$1"
+			}
+			assert.Equal(t, tc.expected, Like{Dialect: tc.dialect}.Condition("name", placeholder))
+		}
+	})
+}
+
+func TestWhere(t *testing.T) {
+	yes, no, zero := true, false, 0
+	page := PageRequest{Offset: 40, Limit: 20, Sort: []SortOrder{{Field: "created_at", Column: "created_at", Desc: true}}}
+
+	testCases := []struct {
+		name    string
+		dialect Dialect
+		build   func(w *Where) (string, []any)
+		sql     string
+		args    []any
+	}{
+		{
+			name:    "no conditions",
+			dialect: DialectPostgres,
+			build: func(w *Where) (string, []any) {
+				return w.Eq("status", "").In("id", []int{}).Like("name", "").Between("age", 0, 0).IsNull("deleted_at", nil).
+					Query("SELECT * FROM users")
+			},
+			sql: "SELECT * FROM users",
+		},
+		{
+			name:    "mysql",
+			dialect: DialectMySQL,
+			build: func(w *Where) (string, []any) {
+				return w.Eq("status", "active").In("role", []string{"admin", "owner"}).Like("name", "50%").
+					Query("SELECT * FROM users")
+			},
+			sql:  `SELECT * FROM users WHERE status = ? AND role IN (?, ?) AND name LIKE ? ESCAPE '\\'`,
+			args: []any{"active", "admin", "owner", `%50\%%`},
+		},
+		{
+			name:    "postgres",
+			dialect: DialectPostgres,
+			build: func(w *Where) (string, []any) {
+				return w.Eq("status", "active").In("role", []string{"admin", "owner"}).Like("name", "a_b").
+					Query("SELECT * FROM users")
+			},
+			sql:  `SELECT * FROM users WHERE status = $1 AND role IN ($2, $3) AND name ILIKE $4 ESCAPE '\'`,
+			args: []any{"active", "admin", "owner", `%a\_b%`},
+		},
+		{
+			name:    "sqlite prefix",
+			dialect: DialectSQLite,
+			build: func(w *Where) (string, []any) {
+				return w.LikePrefix("email", "bob").Build()
+			},
+			sql:  `WHERE email LIKE ? ESCAPE '\'`,
+			args: []any{"bob%"},
+		},
+		{
+			name:    "between",
+			dialect: DialectPostgres,
+			build: func(w *Where) (string, []any) {
+				return w.Between("age", 18, 65).Between("score", 10, nil).Between("height", nil, 200).Build()
+			},
+			sql:  "WHERE age BETWEEN $1 AND $2 AND score >= $3 AND height <= $4",
+			args: []any{18, 65, 10, 200},
+		},
+		{
+			name:    "null and pointers",
+			dialect: DialectMySQL,
+			build: func(w *Where) (string, []any) {
+				return w.IsNull("deleted_at", &yes).IsNull("verified_at", &no).Eq("balance", &zero).Build()
+			},
+			sql:  "WHERE deleted_at IS NULL AND verified_at IS NOT NULL AND balance = ?",
+			args: []any{0},
+		},
+		{
+			name:    "raw",
+			dialect: DialectPostgres,
+			build: func(w *Where) (string, []any) {
+				return w.Eq("tenant_id", 7).Raw("owner_id = ? OR shared = ?", 3, true).Build()
+			},
+			sql:  "WHERE tenant_id = $1 AND (owner_id = $2 OR shared = $3)",
+			args: []any{7, 3, true},
+		},
+		{
+			name:    "raw literal question marks",
+			dialect: DialectPostgres,
+			build: func(w *Where) (string, []any) {
+				return w.Raw("tags ? 'vip'").Raw("owner_id = ? AND note <> '?'", 3).Eq("status", "active").Build()
+			},
+			sql:  "WHERE (tags ? 'vip') AND (owner_id = $1 AND note <> '?') AND status = $2",
+			args: []any{3, "active"},
+		},
+		{
+			name:    "in with a single value",
+			dialect: DialectMySQL,
+			build: func(w *Where) (string, []any) {
+				return w.In("id", 5).Build()
+			},
+			sql:  "WHERE id = ?",
+			args: []any{5},
+		},
+		{
+			name:    "page mysql",
+			dialect: DialectMySQL,
+			build: func(w *Where) (string, []any) {
+				return w.Eq("status", "active").PageQuery("SELECT * FROM orders", page)
+			},
+			sql:  "SELECT * FROM orders WHERE status = ? ORDER BY created_at DESC LIMIT ? OFFSET ?",
+			args: []any{"active", 20, 40},
+		},
+		{
+			name:    "page postgres",
+			dialect: DialectPostgres,
+			build: func(w *Where) (string, []any) {
+				return w.Eq("status", "active").PageQuery("SELECT * FROM orders", page)
+			},
+			sql:  "SELECT * FROM orders WHERE status = $1 ORDER BY created_at DESC LIMIT $2 OFFSET $3",
+			args: []any{"active", 20, 40},
+		},
+		{
+			name:    "page without conditions",
+			dialect: DialectPostgres,
+			build: func(w *Where) (string, []any) {
+				return w.PageQuery("SELECT * FROM orders", PageRequest{Limit: 10})
+			},
+			sql:  "SELECT * FROM orders LIMIT $1 OFFSET $2",
+			args: []any{10, 0},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sql, args := tc.build(NewWhere(tc.dialect))
+			assert.Equal(t, tc.sql, sql)
+			assert.Equal(t, tc.args, args)
+		})
+	}
+}
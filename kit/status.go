@@ -0,0 +1,43 @@
+package kit
+
+import "net/http"
+
+// StatusMapping maps business codes to HTTP status codes.
+// By default the status is the one registered in DefaultCodeRegistry, or is derived
+// from the leading three digits of the code, so ErrNotFound (40400) becomes 404
+// and ErrUnavailable (50300) becomes 503.
+type StatusMapping struct {
+	AlwaysOK  bool        // Respond with 200 for every code, as legacy clients expect
+	Overrides map[int]int // Per-code HTTP status overrides, take precedence over the derived status
+}
+
+// DefaultStatusMapping is the mapping used by TranslateFunc.
+var DefaultStatusMapping = &StatusMapping{}
+
+// Status returns the HTTP status code for the given business code.
+func (m *StatusMapping) Status(code int) int {
+	if m.AlwaysOK {
+		return http.StatusOK
+	}
+	if status, ok := m.Overrides[code]; ok {
+		return status
+	}
+	if info, ok := DefaultCodeRegistry.Lookup(code); ok {
+		return info.HTTPStatus
+	}
+	return HTTPStatus(code)
+}
+
+// HTTPStatus derives the HTTP status code from a business code.
+// OK maps to 200, codes in the 40000-59999 range map to code / 100,
+// and anything else, such as InternalErrorCode, maps to 500.
+func HTTPStatus(code int) int {
+	if code == OK {
+		return http.StatusOK
+	}
+	status := code / 100
+	if status >= http.StatusBadRequest && status < 600 {
+		return status
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,50 @@
+package kit
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPStatus(t *testing.T) {
+	testCases := []struct {
+		name     string
+		code     int
+		expected int
+	}{
+		{"OK", OK, http.StatusOK},
+		{"InvalidArgument", ErrInvalidArgument, http.StatusBadRequest},
+		{"custom 400 code", 40010, http.StatusBadRequest},
+		{"Unauthenticated", ErrUnauthenticated, http.StatusUnauthorized},
+		{"ResourceExhausted", ErrResourceExhausted, http.StatusTooManyRequests},
+		{"Unavailable", ErrUnavailable, http.StatusServiceUnavailable},
+		{"InternalErrorCode", InternalErrorCode, http.StatusInternalServerError},
+		{"out of range code", 12345, http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, HTTPStatus(tc.code))
+		})
+	}
+}
+
+func TestStatusMapping(t *testing.T) {
+	t.Run("derived by default", func(t *testing.T) {
+		m := &StatusMapping{}
+		assert.Equal(t, http.StatusNotFound, m.Status(ErrNotFound))
+	})
+
+	t.Run("always ok", func(t *testing.T) {
+		m := &StatusMapping{AlwaysOK: true, Overrides: map[int]int{ErrNotFound: http.StatusGone}}
+		assert.Equal(t, http.StatusOK, m.Status(ErrNotFound))
+		assert.Equal(t, http.StatusOK, m.Status(InternalErrorCode))
+	})
+
+	t.Run("override", func(t *testing.T) {
+		m := &StatusMapping{Overrides: map[int]int{ErrNotFound: http.StatusGone}}
+		assert.Equal(t, http.StatusGone, m.Status(ErrNotFound))
+		assert.Equal(t, http.StatusConflict, m.Status(ErrAlreadyExists))
+	})
+}
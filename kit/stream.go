@@ -0,0 +1,195 @@
+package kit
+
+import (
+	"encoding/json"
+	"fmt"
+	"iter"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// StreamFormat is the wire format of a streamed response.
+type StreamFormat int
+
+const (
+	// StreamSSE writes each item as a Server-Sent Event whose data is the item JSON.
+	// A failure is sent as a final event named "error" whose data is the failed RespBody.
+	StreamSSE StreamFormat = iota
+	// StreamNDJSON writes one RespBody per line, each item being the resp_data of a succeeded body.
+	// A failure is sent as a final failed RespBody line.
+	StreamNDJSON
+)
+
+// StreamFunc produces the items of a streamed response.
+// An error returned before iterating is written as a regular RespBody with its HTTP status.
+// Once the stream started, an error yielded by the sequence ends it with a terminal error event.
+type StreamFunc func(ctx *gin.Context) (iter.Seq2[any, error], error)
+
+// Event is a stream item carrying Server-Sent Event metadata, NDJSON only writes its Data.
+type Event struct {
+	ID   string // Event id, lets clients resume through Last-Event-ID
+	Name string // Event name, the default "message" when empty
+	Data any    // Event data
+}
+
+// ChanSeq adapts channels to the sequence of a StreamFunc.
+// The stream ends once items is closed and errs, when not nil, is closed or receives nil,
+// and fails with the first error received from errs. It also ends when ctx.Request.Context()
+// is done, even while the producer is idle, and producers should then stop sending.
+func ChanSeq[T any](ctx *gin.Context, items <-chan T, errs <-chan error) iter.Seq2[any, error] {
+	done := ctx.Request.Context().Done()
+	return func(yield func(any, error) bool) {
+		for items != nil || errs != nil {
+			select {
+			case <-done:
+				return
+			case item, ok := <-items:
+				if !ok {
+					items = nil
+					continue
+				}
+				if !yield(item, nil) {
+					return
+				}
+			case err, ok := <-errs:
+				if !ok || err == nil {
+					errs = nil
+					continue
+				}
+				yield(nil, err)
+				return
+			}
+		}
+	}
+}
+
+// Stream converts a StreamFunc into a gin.HandlerFunc writing its items in format.
+// Every item is flushed as soon as it is written, and the stream stops once the client
+// disconnects, which also stops the sequence. Panics are recovered like in Translate.
+func (t *Translator) Stream(format StreamFormat, fun StreamFunc) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if ctx.IsAborted() {
+			return
+		}
+
+		started := false
+		defer func() {
+			if r := recover(); r != nil {
+				if !started {
+					t.handlePanic(ctx, r)
+					return
+				}
+				t.logPanic(ctx, r)
+				t.writeStreamError(ctx, format, NewInternalError().WithErr(fmt.Errorf("panic: %v", r)))
+				ctx.Abort()
+			}
+		}()
+
+		seq, err := fun(ctx)
+		if err != nil {
+			t.Respond(ctx, nil, err)
+			return
+		}
+
+		header := ctx.Writer.Header()
+		header.Set("Cache-Control", "no-cache")
+		header.Set("X-Accel-Buffering", "no")
+		if format == StreamSSE {
+			header.Set("Content-Type", "text/event-stream")
+			header.Set("Connection", "keep-alive")
+		} else {
+			header.Set("Content-Type", "application/x-ndjson")
+		}
+		ctx.Status(http.StatusOK)
+		ctx.Writer.Flush()
+		started = true
+
+		done := ctx.Request.Context().Done()
+		for item, err := range seq {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			if err != nil {
+				t.writeStreamError(ctx, format, err)
+				return
+			}
+
+			frame, err := t.streamFrame(format, item)
+			if err != nil {
+				t.writeStreamError(ctx, format, NewInternalError().WithErr(err))
+				return
+			}
+			if _, err := ctx.Writer.WriteString(frame); err != nil {
+				return
+			}
+			ctx.Writer.Flush()
+		}
+	}
+}
+
+// Stream returns the Stream handler of DefaultTranslator.
+func Stream(format StreamFormat, fun StreamFunc) gin.HandlerFunc {
+	return DefaultTranslator.Stream(format, fun)
+}
+
+// Stream registers a streamed route. Middlewares do not apply to streams,
+// and streamed routes are not recorded in Routes since their responses are not a RespBody.
+func (r *RouterGroup) Stream(httpMethod, relativePath string, format StreamFormat, fun StreamFunc) *RouterGroup {
+	r.gin.Handle(httpMethod, relativePath, r.translator.Stream(format, fun))
+	return r
+}
+
+func (t *Translator) streamFrame(format StreamFormat, item any) (string, error) {
+	event, ok := item.(Event)
+	if !ok {
+		event = Event{Data: item}
+	}
+
+	if format == StreamNDJSON {
+		data, err := json.Marshal(t.wrap(RespBody{Succeeded: true, RespData: event.Data}))
+		if err != nil {
+			return "", err
+		}
+		return string(data) + "\n", nil
+	}
+
+	data, err := json.Marshal(event.Data)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	if event.ID != "" {
+		fmt.Fprintf(&b, "id: %s\n", sanitizeEventField(event.ID))
+	}
+	if event.Name != "" {
+		fmt.Fprintf(&b, "event: %s\n", sanitizeEventField(event.Name))
+	}
+	fmt.Fprintf(&b, "data: %s\n\n", data)
+	return b.String(), nil
+}
+
+// writeStreamError ends a started stream with the failed RespBody of err.
+func (t *Translator) writeStreamError(ctx *gin.Context, format StreamFormat, err error) {
+	respBody := t.ErrorBody(ctx, err)
+	t.getLogger().Warnf("failed to stream http, code: %d, info: %s, desc: %s", respBody.Code, respBody.Info, respBody.Desc)
+
+	data, marshalErr := json.Marshal(t.wrap(respBody))
+	if marshalErr != nil {
+		return
+	}
+	if format == StreamNDJSON {
+		_, _ = ctx.Writer.WriteString(string(data) + "\n")
+	} else {
+		_, _ = ctx.Writer.WriteString("event: error\ndata: " + string(data) + "\n\n")
+	}
+	ctx.Writer.Flush()
+}
+
+// sanitizeEventField keeps a field on a single line, a line break would start a new field.
+func sanitizeEventField(s string) string {
+	return strings.NewReplacer("\r", "", "\n", "").Replace(s)
+}
@@ -0,0 +1,280 @@
+package kit
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func serveStream(format StreamFormat, fun StreamFunc) *httptest.ResponseRecorder {
+	r := gin.New()
+	translator := NewTranslator(WithLogger(zap.NewNop().Sugar()))
+	NewRouterGroupWithTranslator(&r.RouterGroup, translator).Stream(http.MethodGet, "/stream", format, fun)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/stream", http.NoBody)
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func seqOf(items ...any) iter.Seq2[any, error] {
+	return func(yield func(any, error) bool) {
+		for _, item := range items {
+			if err, ok := item.(error); ok {
+				yield(nil, err)
+				return
+			}
+			if !yield(item, nil) {
+				return
+			}
+		}
+	}
+}
+
+func TestStream_SSE(t *testing.T) {
+	w := serveStream(StreamSSE, func(ctx *gin.Context) (iter.Seq2[any, error], error) {
+		return seqOf(
+			map[string]int{"progress": 50},
+			Event{ID: "2", Name: "progress\nevent: spoofed", Data: map[string]int{"progress": 100}},
+		), nil
+	})
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/event-stream", w.Header().Get("Content-Type"))
+	assert.Equal(t, "no-cache", w.Header().Get("Cache-Control"))
+	assert.Equal(t, "data: {\"progress\":50}\n\n"+
+		"id: 2\nevent: progressevent: spoofed\ndata: {\"progress\":100}\n\n", w.Body.String())
+}
+
+func TestStream_NDJSON(t *testing.T) {
+	w := serveStream(StreamNDJSON, func(ctx *gin.Context) (iter.Seq2[any, error], error) {
+		return seqOf(1, Event{Name: "ignored", Data: 2}), nil
+	})
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/x-ndjson", w.Header().Get("Content-Type"))
+	assert.Equal(t, "{\"succeeded\":true,\"resp_data\":1}\n{\"succeeded\":true,\"resp_data\":2}\n", w.Body.String())
+}
+
+func TestStream_Errors(t *testing.T) {
+	t.Run("before the stream", func(t *testing.T) {
+		w := serveStream(StreamSSE, func(ctx *gin.Context) (iter.Seq2[any, error], error) {
+			return nil, NewNotFoundError()
+		})
+
+		respBody := RespBody{}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &respBody))
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Equal(t, ErrNotFound, respBody.Code)
+	})
+
+	t.Run("SSE terminal event", func(t *testing.T) {
+		w := serveStream(StreamSSE, func(ctx *gin.Context) (iter.Seq2[any, error], error) {
+			return seqOf(1, NewUnavailableError().WithInfo("export interrupted"), 2), nil
+		})
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "data: 1\n\n"+
+			"event: error\ndata: {\"succeeded\":false,\"resp_data\":null,\"code\":50300,\"info\":\"export interrupted\"}\n\n",
+			w.Body.String())
+	})
+
+	t.Run("NDJSON terminal line", func(t *testing.T) {
+		w := serveStream(StreamNDJSON, func(ctx *gin.Context) (iter.Seq2[any, error], error) {
+			return seqOf(1, errors.New("disk full")), nil
+		})
+
+		lines := strings.Split(strings.TrimSuffix(w.Body.String(), "\n"), "\n")
+		assert.Len(t, lines, 2)
+		respBody := RespBody{}
+		assert.NoError(t, json.Unmarshal([]byte(lines[1]), &respBody))
+		assert.False(t, respBody.Succeeded)
+		assert.Equal(t, InternalErrorCode, respBody.Code)
+		assert.Equal(t, "disk full", respBody.Desc) // tests run in debug mode
+	})
+
+	t.Run("unsupported item", func(t *testing.T) {
+		w := serveStream(StreamSSE, func(ctx *gin.Context) (iter.Seq2[any, error], error) {
+			return seqOf(make(chan int)), nil
+		})
+
+		assert.Contains(t, w.Body.String(), fmt.Sprintf("event: error\ndata: {\"succeeded\":false,\"resp_data\":null,\"code\":%d", ErrInternal))
+	})
+
+	t.Run("panic before the stream", func(t *testing.T) {
+		w := serveStream(StreamSSE, func(ctx *gin.Context) (iter.Seq2[any, error], error) {
+			panic("broken")
+		})
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Contains(t, w.Body.String(), "panic: broken")
+	})
+
+	t.Run("panic in the stream", func(t *testing.T) {
+		w := serveStream(StreamNDJSON, func(ctx *gin.Context) (iter.Seq2[any, error], error) {
+			return func(yield func(any, error) bool) {
+				yield(1, nil)
+				panic("broken")
+			}, nil
+		})
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "{\"succeeded\":true,\"resp_data\":1}\n"+
+			fmt.Sprintf("{\"succeeded\":false,\"resp_data\":null,\"code\":%d,\"info\":%q,\"desc\":\"panic: broken\"}\n",
+				ErrInternal, Messages[ErrInternal]),
+			w.Body.String())
+	})
+}
+
+func TestStream_ClientDisconnect(t *testing.T) {
+	stopped := make(chan struct{})
+	r := gin.New()
+	r.GET("/stream", NewTranslator(WithLogger(zap.NewNop().Sugar())).Stream(StreamNDJSON,
+		func(ctx *gin.Context) (iter.Seq2[any, error], error) {
+			return func(yield func(any, error) bool) {
+				defer close(stopped)
+				for i := 0; ; i++ {
+					if !yield(i, nil) {
+						return
+					}
+					time.Sleep(time.Millisecond)
+				}
+			}, nil
+		}))
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/stream", http.NoBody)
+	resp, err := server.Client().Do(req)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	line, err := bufio.NewReader(resp.Body).ReadString('\n')
+	assert.NoError(t, err)
+	assert.Equal(t, "{\"succeeded\":true,\"resp_data\":0}\n", line)
+	cancel()
+
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("stream kept running after the client disconnected")
+	}
+}
+
+func TestStream_ClientDisconnectIdleProducer(t *testing.T) {
+	finished := make(chan struct{})
+	items := make(chan int, 1)
+	r := gin.New()
+	r.GET("/stream", func(ctx *gin.Context) {
+		defer close(finished)
+		ctx.Next()
+	}, NewTranslator(WithLogger(zap.NewNop().Sugar())).Stream(StreamNDJSON,
+		func(ctx *gin.Context) (iter.Seq2[any, error], error) {
+			items <- 0 // the producer then stays idle without closing items
+			return ChanSeq(ctx, items, nil), nil
+		}))
+	server := httptest.NewServer(r)
+	defer server.Close()
+	defer close(items) // unblocks a stuck handler so that the server can close
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/stream", http.NoBody)
+	resp, err := server.Client().Do(req)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	line, err := bufio.NewReader(resp.Body).ReadString('\n')
+	assert.NoError(t, err)
+	assert.Equal(t, "{\"succeeded\":true,\"resp_data\":0}\n", line)
+	cancel()
+
+	select {
+	case <-finished:
+	case <-time.After(5 * time.Second):
+		t.Fatal("handler kept waiting for the idle producer after the client disconnected")
+	}
+}
+
+func TestChanSeq(t *testing.T) {
+	collect := func(seq iter.Seq2[any, error]) ([]any, error) {
+		var items []any
+		for item, err := range seq {
+			if err != nil {
+				return items, err
+			}
+			items = append(items, item)
+		}
+		return items, nil
+	}
+
+	t.Run("items only", func(t *testing.T) {
+		items := make(chan int, 2)
+		items <- 1
+		items <- 2
+		close(items)
+
+		got, err := collect(ChanSeq(newLocaleContext(nil), items, nil))
+		assert.NoError(t, err)
+		assert.Equal(t, []any{1, 2}, got)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		items := make(chan int)
+		errs := make(chan error, 1)
+		go func() {
+			items <- 1
+			errs <- NewAbortedError()
+		}()
+
+		got, err := collect(ChanSeq(newLocaleContext(nil), items, errs))
+		assert.ErrorIs(t, err, NewAbortedError())
+		assert.Equal(t, []any{1}, got)
+	})
+
+	t.Run("closed error channel", func(t *testing.T) {
+		items := make(chan string, 1)
+		errs := make(chan error)
+		items <- "a"
+		close(items)
+		close(errs)
+
+		got, err := collect(ChanSeq(newLocaleContext(nil), items, errs))
+		assert.NoError(t, err)
+		assert.Equal(t, []any{"a"}, got)
+	})
+
+	t.Run("done context", func(t *testing.T) {
+		ctx := newLocaleContext(nil)
+		reqCtx, cancel := context.WithCancel(context.Background())
+		ctx.Request = ctx.Request.WithContext(reqCtx)
+		cancel()
+
+		got, err := collect(ChanSeq(ctx, make(chan int), nil))
+		assert.NoError(t, err)
+		assert.Empty(t, got)
+	})
+
+	t.Run("early break", func(t *testing.T) {
+		items := make(chan int, 2)
+		items <- 1
+		items <- 2
+
+		for item := range ChanSeq(newLocaleContext(nil), items, nil) {
+			assert.Equal(t, 1, item)
+			break
+		}
+	})
+}
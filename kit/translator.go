@@ -0,0 +1,251 @@
+package kit
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// EnvelopeFunc builds the value written to the client from a RespBody.
+// It allows services to wrap or reshape the standard response envelope.
+type EnvelopeFunc func(body RespBody) any
+
+// TranslatorOption configures a Translator.
+type TranslatorOption func(t *Translator)
+
+// Translator converts HandlerFunc results into HTTP responses.
+// The zero configuration matches the behavior of TranslateFunc.
+type Translator struct {
+	logger       *zap.SugaredLogger
+	debug        func(ctx *gin.Context) bool
+	internalCode int
+	envelope     EnvelopeFunc
+	status       *StatusMapping
+	catalog      *Catalog
+}
+
+// DefaultTranslator is the Translator used by TranslateFunc and NewRouterGroup.
+var DefaultTranslator = NewTranslator()
+
+// NewTranslator creates a Translator with the given options applied.
+func NewTranslator(opts ...TranslatorOption) *Translator {
+	t := &Translator{
+		internalCode: InternalErrorCode,
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
+}
+
+// WithLogger sets the logger used to report failed requests.
+// Defaults to zap.S().Named("TranslateFunc").
+func WithLogger(logger *zap.SugaredLogger) TranslatorOption {
+	return func(t *Translator) {
+		t.logger = logger
+	}
+}
+
+// WithDebugDetail sets the policy deciding whether Desc is exposed to the client.
+// Defaults to gin.IsDebugging().
+func WithDebugDetail(policy func(ctx *gin.Context) bool) TranslatorOption {
+	return func(t *Translator) {
+		t.debug = policy
+	}
+}
+
+// WithInternalErrorCode sets the code reported for errors that are not a BusinessError.
+// Defaults to InternalErrorCode.
+func WithInternalErrorCode(code int) TranslatorOption {
+	return func(t *Translator) {
+		t.internalCode = code
+	}
+}
+
+// WithEnvelope sets the builder for the value written to the client.
+// Defaults to writing the RespBody as is.
+func WithEnvelope(envelope EnvelopeFunc) TranslatorOption {
+	return func(t *Translator) {
+		t.envelope = envelope
+	}
+}
+
+// WithStatusMapping sets the mapping from business codes to HTTP status codes.
+// Defaults to DefaultStatusMapping.
+func WithStatusMapping(mapping *StatusMapping) TranslatorOption {
+	return func(t *Translator) {
+		t.status = mapping
+	}
+}
+
+// WithCatalog sets the catalog used to render Info in the language of the request.
+// Defaults to DefaultCatalog.
+func WithCatalog(catalog *Catalog) TranslatorOption {
+	return func(t *Translator) {
+		t.catalog = catalog
+	}
+}
+
+// Translate converts a HandlerFunc into a gin.HandlerFunc that writes the result
+// through Respond. Panics are recovered and written as an ErrInternal RespBody.
+func (t *Translator) Translate(fun HandlerFunc) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if ctx.IsAborted() {
+			return
+		}
+		defer func() {
+			if r := recover(); r != nil {
+				t.handlePanic(ctx, r)
+			}
+		}()
+
+		resp, err := fun(ctx)
+		t.Respond(ctx, resp, err)
+	}
+}
+
+// Recovery returns a middleware that recovers panics of the following handlers,
+// including plain gin handlers, and writes them as an ErrInternal RespBody.
+// Use it in place of gin.Recovery to keep the response envelope.
+func (t *Translator) Recovery() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		defer func() {
+			if r := recover(); r != nil {
+				t.handlePanic(ctx, r)
+			}
+		}()
+		ctx.Next()
+	}
+}
+
+// Recovery returns the Recovery middleware of DefaultTranslator.
+func Recovery() gin.HandlerFunc {
+	return DefaultTranslator.Recovery()
+}
+
+// handlePanic logs the panic with its stack and request metadata, then writes
+// an ErrInternal RespBody. The panic value only reaches Desc in debug mode.
+func (t *Translator) handlePanic(ctx *gin.Context, r any) {
+	t.logPanic(ctx, r)
+
+	if ctx.Writer.Written() {
+		ctx.Abort()
+		return
+	}
+
+	respBody := t.ErrorBody(ctx, NewInternalError().WithErr(fmt.Errorf("panic: %v", r)))
+	ctx.AbortWithStatusJSON(t.getStatus().Status(respBody.Code), t.wrap(respBody))
+}
+
+// logPanic logs a recovered panic with its stack and request metadata.
+// http.ErrAbortHandler is panicked again, so that net/http aborts the response silently.
+func (t *Translator) logPanic(ctx *gin.Context, r any) {
+	if r == http.ErrAbortHandler {
+		panic(r)
+	}
+
+	t.getLogger().Errorw("recovered from panic",
+		"method", ctx.Request.Method,
+		"path", ctx.Request.URL.Path,
+		"client_ip", ctx.ClientIP(),
+		"panic", r,
+		"stack", string(debug.Stack()),
+	)
+}
+
+// Respond writes resp or err to the client in the RespBody envelope.
+func (t *Translator) Respond(ctx *gin.Context, resp any, err error) {
+	if err != nil {
+		respBody := t.ErrorBody(ctx, err)
+		t.getLogger().Warnf("failed to handler http, code: %d, info: %s, desc: %s", respBody.Code, respBody.Info, respBody.Desc)
+		ctx.JSON(t.getStatus().Status(respBody.Code), t.wrap(respBody))
+		return
+	}
+
+	ctx.JSON(t.getStatus().Status(OK), t.wrap(RespBody{Succeeded: true, RespData: resp}))
+}
+
+// ErrorBody builds the failed RespBody for err.
+// A BusinessError anywhere in the wrap chain of err is reported with its own code.
+// Info is rendered in the language of the request unless it was customized.
+// Desc is only filled when the debug detail policy allows it.
+func (t *Translator) ErrorBody(ctx *gin.Context, err error) RespBody {
+	respBody := RespBody{
+		Succeeded: false,
+	}
+
+	var ex BusinessError
+	if errors.As(err, &ex) {
+		respBody.Code = ex.Code()
+		respBody.Info = t.localize(ctx, ex.Code(), ex.Info())
+		if t.isDebugging(ctx) {
+			respBody.Desc = ex.Desc()
+		}
+		if detailed, ok := ex.(DetailedError); ok {
+			respBody.Details = detailed.Details()
+		}
+		return respBody
+	}
+
+	respBody.Code = t.internalCode
+	respBody.Info = t.localize(ctx, ErrInternal, Messages[ErrInternal])
+	if t.isDebugging(ctx) {
+		respBody.Desc = err.Error()
+	}
+	return respBody
+}
+
+// localize renders the default message of code in the language of the request,
+// custom infos set through WithInfo are kept as is.
+func (t *Translator) localize(ctx *gin.Context, code int, info string) string {
+	catalog := t.getCatalog()
+	if info != "" && info != Messages[code] {
+		if message, ok := catalog.Message(catalog.defaultLocale, code); !ok || info != message {
+			return info
+		}
+	}
+
+	if message, ok := catalog.Message(catalog.Negotiate(ctx), code); ok {
+		return message
+	}
+	return info
+}
+
+func (t *Translator) wrap(body RespBody) any {
+	if t.envelope == nil {
+		return body
+	}
+	return t.envelope(body)
+}
+
+func (t *Translator) isDebugging(ctx *gin.Context) bool {
+	if t.debug == nil {
+		return gin.IsDebugging()
+	}
+	return t.debug(ctx)
+}
+
+func (t *Translator) getLogger() *zap.SugaredLogger {
+	if t.logger == nil {
+		return zap.S().Named("TranslateFunc")
+	}
+	return t.logger
+}
+
+func (t *Translator) getCatalog() *Catalog {
+	if t.catalog == nil {
+		return DefaultCatalog
+	}
+	return t.catalog
+}
+
+func (t *Translator) getStatus() *StatusMapping {
+	if t.status == nil {
+		return DefaultStatusMapping
+	}
+	return t.status
+}
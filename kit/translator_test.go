@@ -0,0 +1,126 @@
+package kit
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func serveTranslator(translator *Translator, handler HandlerFunc) *httptest.ResponseRecorder {
+	r := gin.New()
+	NewRouterGroupWithTranslator(&r.RouterGroup, translator).GET("/test", handler)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/test", http.NoBody)
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestNewTranslator_Defaults(t *testing.T) {
+	w := serveTranslator(NewTranslator(), func(ctx *gin.Context) (any, error) {
+		return nil, errors.New("boom")
+	})
+
+	respBody := RespBody{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &respBody))
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Equal(t, InternalErrorCode, respBody.Code)
+	assert.Equal(t, Messages[ErrInternal], respBody.Info)
+	assert.Equal(t, "boom", respBody.Desc) // tests run in debug mode
+}
+
+func TestTranslator_Options(t *testing.T) {
+	t.Run("logger", func(t *testing.T) {
+		core, recorded := observer.New(zapcore.WarnLevel)
+		translator := NewTranslator(WithLogger(zap.New(core).Sugar()))
+
+		serveTranslator(translator, func(ctx *gin.Context) (any, error) {
+			return nil, NewNotFoundError()
+		})
+		assert.Equal(t, 1, recorded.Len())
+	})
+
+	t.Run("debug detail policy", func(t *testing.T) {
+		translator := NewTranslator(WithDebugDetail(func(ctx *gin.Context) bool {
+			return ctx.GetHeader("X-Debug") != ""
+		}))
+
+		w := serveTranslator(translator, func(ctx *gin.Context) (any, error) {
+			return nil, NewNotFoundError().WithErr(errors.New("secret"))
+		})
+
+		respBody := RespBody{}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &respBody))
+		assert.Equal(t, "", respBody.Desc)
+	})
+
+	t.Run("internal error code", func(t *testing.T) {
+		translator := NewTranslator(WithInternalErrorCode(ErrInternal))
+
+		w := serveTranslator(translator, func(ctx *gin.Context) (any, error) {
+			return nil, errors.New("boom")
+		})
+
+		respBody := RespBody{}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &respBody))
+		assert.Equal(t, ErrInternal, respBody.Code)
+	})
+
+	t.Run("envelope", func(t *testing.T) {
+		translator := NewTranslator(WithEnvelope(func(body RespBody) any {
+			return gin.H{"ok": body.Succeeded, "data": body.RespData}
+		}))
+
+		w := serveTranslator(translator, func(ctx *gin.Context) (any, error) {
+			return "hello", nil
+		})
+		assert.JSONEq(t, `{"ok":true,"data":"hello"}`, w.Body.String())
+	})
+
+	t.Run("details", func(t *testing.T) {
+		w := serveTranslator(NewTranslator(), func(ctx *gin.Context) (any, error) {
+			return nil, NewResourceExhaustedError().WithDetails(
+				QuotaFailure{Violations: []QuotaViolation{{Subject: "user:1", Description: "daily limit"}}},
+				RetryInfo{RetryDelay: time.Minute},
+			)
+		})
+
+		respBody := RespBody{}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &respBody))
+		assert.Equal(t, Details{
+			QuotaFailure{Violations: []QuotaViolation{{Subject: "user:1", Description: "daily limit"}}},
+			RetryInfo{RetryDelay: time.Minute},
+		}, respBody.Details)
+	})
+
+	t.Run("wrapped business error", func(t *testing.T) {
+		w := serveTranslator(NewTranslator(), func(ctx *gin.Context) (any, error) {
+			return nil, fmt.Errorf("load user: %w", NewNotFoundError())
+		})
+
+		respBody := RespBody{}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &respBody))
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Equal(t, ErrNotFound, respBody.Code)
+		assert.Equal(t, Messages[ErrNotFound], respBody.Info)
+	})
+
+	t.Run("status mapping", func(t *testing.T) {
+		translator := NewTranslator(WithStatusMapping(&StatusMapping{AlwaysOK: true}))
+
+		w := serveTranslator(translator, func(ctx *gin.Context) (any, error) {
+			return nil, NewNotFoundError()
+		})
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
@@ -0,0 +1,107 @@
+package kit
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync/atomic"
+)
+
+// savepointSeq makes savepoint names unique, whatever the nesting depth.
+var savepointSeq atomic.Uint64
+
+// WithTx runs fn inside a transaction, committing when it succeeds and rolling back when it
+// returns an error or panics. Panics are recovered and returned as an ErrInternal Exception.
+// Rollback failures are joined to the error of fn.
+func WithTx(ctx context.Context, db *sql.DB, opts *sql.TxOptions, fn func(tx *sql.Tx) error) (err error) {
+	tx, err := db.BeginTx(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = NewInternalError().WithErr(fmt.Errorf("panic in transaction: %v", r))
+		}
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil && !errors.Is(rollbackErr, sql.ErrTxDone) {
+				err = errors.Join(err, fmt.Errorf("rollback: %w", rollbackErr))
+			}
+			return
+		}
+		if commitErr := tx.Commit(); commitErr != nil {
+			err = fmt.Errorf("commit: %w", commitErr)
+		}
+	}()
+
+	return fn(tx)
+}
+
+// WithSavepoint runs fn inside a savepoint of tx, so that a nested unit of work can fail
+// without aborting the whole transaction. The savepoint is released when fn succeeds and
+// rolled back to when it returns an error or panics, panics being recovered like in WithTx.
+func WithSavepoint(ctx context.Context, tx *sql.Tx, fn func(tx *sql.Tx) error) (err error) {
+	name := "kit_sp_" + strconv.FormatUint(savepointSeq.Add(1), 10)
+	if _, err := tx.ExecContext(ctx, "SAVEPOINT "+name); err != nil {
+		return fmt.Errorf("savepoint: %w", err)
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = NewInternalError().WithErr(fmt.Errorf("panic in savepoint: %v", r))
+		}
+		if err != nil {
+			if _, rollbackErr := tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+name); rollbackErr != nil {
+				err = errors.Join(err, fmt.Errorf("rollback to savepoint: %w", rollbackErr))
+			}
+			return
+		}
+		if _, releaseErr := tx.ExecContext(ctx, "RELEASE SAVEPOINT "+name); releaseErr != nil {
+			err = fmt.Errorf("release savepoint: %w", releaseErr)
+		}
+	}()
+
+	return fn(tx)
+}
+
+// TxAlice is the Alice chain of steps sharing a transaction.
+// It executes steps sequentially and stops at the first error.
+type TxAlice struct {
+	tx  *sql.Tx
+	err error
+}
+
+// NewTxAlice creates a chain running its steps in tx, usually inside WithTx.
+func NewTxAlice(tx *sql.Tx) *TxAlice {
+	return &TxAlice{tx: tx}
+}
+
+// Then adds a step to the chain. If a previous step failed, this step will be skipped.
+func (a *TxAlice) Then(next func(tx *sql.Tx) error) *TxAlice {
+	if a.err != nil {
+		return a
+	}
+	a.err = next(a.tx)
+	return a
+}
+
+// Error returns the first error encountered in the chain, or nil if all succeeded.
+func (a *TxAlice) Error() error {
+	return a.err
+}
+
+// NewTx creates and executes a chain of steps inside one transaction, committing when all
+// of them succeed and rolling back at the first error. The returned Alice carries the error.
+func NewTx(ctx context.Context, db *sql.DB, opts *sql.TxOptions, steps ...func(tx *sql.Tx) error) *Alice {
+	return NewAlice().Then(func() error {
+		return WithTx(ctx, db, opts, func(tx *sql.Tx) error {
+			a := NewTxAlice(tx)
+			for _, step := range steps {
+				a.Then(step)
+			}
+			return a.Error()
+		})
+	})
+}
@@ -0,0 +1,279 @@
+package kit
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// stubDriver records the statements and transaction calls it receives.
+type stubDriver struct {
+	mu        sync.Mutex
+	log       []string
+	beginErr  error
+	commitErr error
+	execErr   map[string]error // Statement prefix to error
+}
+
+func (d *stubDriver) record(entry string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.log = append(d.log, entry)
+}
+
+// statements returns the log with savepoint names normalized, since they are globally numbered.
+func (d *stubDriver) statements() []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	re := regexp.MustCompile(`kit_sp_\d+`)
+	names := map[string]string{}
+	result := make([]string, len(d.log))
+	for i, entry := range d.log {
+		result[i] = re.ReplaceAllStringFunc(entry, func(name string) string {
+			if _, ok := names[name]; !ok {
+				names[name] = "sp" + string(rune('1'+len(names)))
+			}
+			return names[name]
+		})
+	}
+	return result
+}
+
+func (d *stubDriver) Open(string) (driver.Conn, error)             { return &stubConn{driver: d}, nil }
+func (d *stubDriver) Connect(context.Context) (driver.Conn, error) { return &stubConn{driver: d}, nil }
+func (d *stubDriver) Driver() driver.Driver                        { return d }
+
+type stubConn struct {
+	driver *stubDriver
+}
+
+func (c *stubConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (c *stubConn) Close() error                        { return nil }
+func (c *stubConn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+func (c *stubConn) BeginTx(_ context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if c.driver.beginErr != nil {
+		return nil, c.driver.beginErr
+	}
+	if opts.ReadOnly {
+		c.driver.record("BEGIN READ ONLY")
+	} else {
+		c.driver.record("BEGIN")
+	}
+	return &stubTx{driver: c.driver}, nil
+}
+
+func (c *stubConn) ExecContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Result, error) {
+	c.driver.record(query)
+	for prefix, err := range c.driver.execErr {
+		if strings.HasPrefix(query, prefix) {
+			return nil, err
+		}
+	}
+	return driver.RowsAffected(1), nil
+}
+
+type stubTx struct {
+	driver *stubDriver
+}
+
+func (tx *stubTx) Commit() error {
+	tx.driver.record("COMMIT")
+	return tx.driver.commitErr
+}
+
+func (tx *stubTx) Rollback() error {
+	tx.driver.record("ROLLBACK")
+	return nil
+}
+
+func newStubDB(t *testing.T, d *stubDriver) *sql.DB {
+	t.Helper()
+	db := sql.OpenDB(d)
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = db.Close() })
+	return db
+}
+
+func exec(query string) func(tx *sql.Tx) error {
+	return func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(context.Background(), query)
+		return err
+	}
+}
+
+func TestWithTx(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("commit", func(t *testing.T) {
+		d := &stubDriver{}
+		err := WithTx(ctx, newStubDB(t, d), &sql.TxOptions{ReadOnly: true}, exec("INSERT 1"))
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"BEGIN READ ONLY", "INSERT 1", "COMMIT"}, d.statements())
+	})
+
+	t.Run("rollback on error", func(t *testing.T) {
+		d := &stubDriver{}
+		failure := NewAbortedError()
+		err := WithTx(ctx, newStubDB(t, d), nil, func(tx *sql.Tx) error {
+			assert.NoError(t, exec("INSERT 1")(tx))
+			return failure
+		})
+		assert.Same(t, failure, err)
+		assert.Equal(t, []string{"BEGIN", "INSERT 1", "ROLLBACK"}, d.statements())
+	})
+
+	t.Run("rollback on panic", func(t *testing.T) {
+		d := &stubDriver{}
+		err := WithTx(ctx, newStubDB(t, d), nil, func(tx *sql.Tx) error {
+			panic("broken")
+		})
+		assert.ErrorIs(t, err, NewInternalError())
+		assert.Equal(t, "panic in transaction: broken", err.(*Exception).Desc())
+		assert.Equal(t, []string{"BEGIN", "ROLLBACK"}, d.statements())
+	})
+
+	t.Run("fn ended the transaction", func(t *testing.T) {
+		d := &stubDriver{}
+		failure := errors.New("failed")
+		err := WithTx(ctx, newStubDB(t, d), nil, func(tx *sql.Tx) error {
+			assert.NoError(t, tx.Rollback())
+			return failure
+		})
+		assert.Same(t, failure, err)
+	})
+
+	t.Run("begin error", func(t *testing.T) {
+		d := &stubDriver{beginErr: errors.New("no connection")}
+		called := false
+		err := WithTx(ctx, newStubDB(t, d), nil, func(tx *sql.Tx) error {
+			called = true
+			return nil
+		})
+		assert.EqualError(t, err, "no connection")
+		assert.False(t, called)
+	})
+
+	t.Run("commit error", func(t *testing.T) {
+		d := &stubDriver{commitErr: errors.New("serialization failure")}
+		err := WithTx(ctx, newStubDB(t, d), nil, exec("INSERT 1"))
+		assert.EqualError(t, err, "commit: serialization failure")
+	})
+}
+
+func TestWithSavepoint(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("nested", func(t *testing.T) {
+		d := &stubDriver{}
+		err := WithTx(ctx, newStubDB(t, d), nil, func(tx *sql.Tx) error {
+			assert.NoError(t, exec("INSERT order")(tx))
+
+			err := WithSavepoint(ctx, tx, func(tx *sql.Tx) error {
+				assert.NoError(t, exec("INSERT coupon")(tx))
+				return WithSavepoint(ctx, tx, exec("INSERT audit"))
+			})
+			assert.NoError(t, err)
+
+			err = WithSavepoint(ctx, tx, func(tx *sql.Tx) error {
+				assert.NoError(t, exec("INSERT points")(tx))
+				return NewResourceExhaustedError()
+			})
+			assert.ErrorIs(t, err, NewResourceExhaustedError())
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{
+			"BEGIN",
+			"INSERT order",
+			"SAVEPOINT sp1",
+			"INSERT coupon",
+			"SAVEPOINT sp2",
+			"INSERT audit",
+			"RELEASE SAVEPOINT sp2",
+			"RELEASE SAVEPOINT sp1",
+			"SAVEPOINT sp3",
+			"INSERT points",
+			"ROLLBACK TO SAVEPOINT sp3",
+			"COMMIT",
+		}, d.statements())
+	})
+
+	t.Run("panic", func(t *testing.T) {
+		d := &stubDriver{}
+		err := WithTx(ctx, newStubDB(t, d), nil, func(tx *sql.Tx) error {
+			return WithSavepoint(ctx, tx, func(tx *sql.Tx) error {
+				panic("broken")
+			})
+		})
+		assert.ErrorIs(t, err, NewInternalError())
+		assert.Equal(t, []string{"BEGIN", "SAVEPOINT sp1", "ROLLBACK TO SAVEPOINT sp1", "ROLLBACK"}, d.statements())
+	})
+
+	t.Run("statement errors", func(t *testing.T) {
+		d := &stubDriver{execErr: map[string]error{
+			"SAVEPOINT":             errors.New("savepoints disabled"),
+			"ROLLBACK TO SAVEPOINT": errors.New("connection lost"),
+		}}
+		db := newStubDB(t, d)
+
+		err := WithTx(ctx, db, nil, func(tx *sql.Tx) error {
+			return WithSavepoint(ctx, tx, exec("INSERT 1"))
+		})
+		assert.EqualError(t, err, "savepoint: savepoints disabled")
+
+		delete(d.execErr, "SAVEPOINT")
+		failure := errors.New("failed")
+		err = WithTx(ctx, db, nil, func(tx *sql.Tx) error {
+			return WithSavepoint(ctx, tx, func(tx *sql.Tx) error { return failure })
+		})
+		assert.ErrorIs(t, err, failure)
+		assert.ErrorContains(t, err, "rollback to savepoint: connection lost")
+	})
+}
+
+func TestTxAlice(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("commit", func(t *testing.T) {
+		d := &stubDriver{}
+		err := NewTx(ctx, newStubDB(t, d), nil, exec("INSERT 1"), exec("INSERT 2")).Error()
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"BEGIN", "INSERT 1", "INSERT 2", "COMMIT"}, d.statements())
+	})
+
+	t.Run("first error rolls back", func(t *testing.T) {
+		d := &stubDriver{}
+		failure := NewAlreadyExistsError()
+		executed := false
+
+		err := NewTx(ctx, newStubDB(t, d), nil,
+			exec("INSERT 1"),
+			func(tx *sql.Tx) error { return failure },
+			func(tx *sql.Tx) error { executed = true; return nil },
+		).Error()
+
+		assert.Same(t, failure, err)
+		assert.False(t, executed)
+		assert.Equal(t, []string{"BEGIN", "INSERT 1", "ROLLBACK"}, d.statements())
+	})
+
+	t.Run("inside WithTx", func(t *testing.T) {
+		d := &stubDriver{}
+		err := WithTx(ctx, newStubDB(t, d), nil, func(tx *sql.Tx) error {
+			return NewTxAlice(tx).Then(exec("INSERT 1")).Then(exec("INSERT 2")).Error()
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"BEGIN", "INSERT 1", "INSERT 2", "COMMIT"}, d.statements())
+	})
+}